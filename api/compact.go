@@ -0,0 +1,117 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/period"
+)
+
+// ResultFormat is the shape of the List workflow results.
+type ResultFormat string
+
+const (
+	// ResultFormatPoints returns the results as a list of time/value points.
+	ResultFormatPoints ResultFormat = "points"
+	// ResultFormatCompact returns the results as a packed list of values.
+	ResultFormatCompact ResultFormat = "compact"
+)
+
+// Validate checks that the result format is supported. An empty format is
+// valid and corresponds to ResultFormatPoints.
+func (f ResultFormat) Validate() error {
+	switch f {
+	case "", ResultFormatPoints, ResultFormatCompact:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownResultFormat, string(f))
+	}
+}
+
+// FormatResults returns the points of the range in the format requested by the
+// parameters.
+func (params ListWorkflowParams) FormatResults(data []SMADataPoint) ListWorkflowResults {
+	if params.Format != ResultFormatCompact {
+		return ListWorkflowResults{Data: data}
+	}
+
+	return ListWorkflowResults{
+		Compact: NewCompactData(params.Start, params.End, params.Period, data),
+	}
+}
+
+// CompactData is a columnar representation of a regular SMA time serie: the
+// value at index i corresponds to the time Start + i*Period.
+type CompactData struct {
+	Start  time.Time     `json:"start"`
+	Period period.Symbol `json:"period"`
+	Values []float64     `json:"values"`
+	// Missing is a bitmap where the bit i (least significant bit first) is
+	// set when there is no value at index i.
+	Missing []byte `json:"missing"`
+}
+
+// NewCompactData packs the data points from start to end into a compact data.
+// Points that are not aligned on the period or out of the range are ignored.
+func NewCompactData(start, end time.Time, per period.Symbol, data []SMADataPoint) *CompactData {
+	interval := per.Duration()
+	count := 0
+	if !end.Before(start) {
+		count = int(end.Sub(start)/interval) + 1
+	}
+
+	// Set every point as missing
+	c := &CompactData{
+		Start:   start,
+		Period:  per,
+		Values:  make([]float64, count),
+		Missing: make([]byte, (count+7)/8),
+	}
+	for i := 0; i < count; i++ {
+		c.Missing[i/8] |= 1 << (i % 8)
+	}
+
+	// Set the values of the existing points
+	for _, d := range data {
+		diff := d.Time.Sub(start)
+		if diff < 0 || diff%interval != 0 {
+			continue
+		}
+
+		i := int(diff / interval)
+		if i >= count {
+			continue
+		}
+
+		c.Values[i] = d.Value
+		c.Missing[i/8] &^= 1 << (i % 8)
+	}
+
+	return c
+}
+
+// IsMissing returns true if there is no value at the given index.
+func (c CompactData) IsMissing(i int) bool {
+	if i < 0 || i >= len(c.Values) || i/8 >= len(c.Missing) {
+		return true
+	}
+	return c.Missing[i/8]&(1<<(i%8)) != 0
+}
+
+// Points unpacks the compact data into a list of time/value points, skipping
+// the missing ones.
+func (c CompactData) Points() []SMADataPoint {
+	interval := c.Period.Duration()
+	points := make([]SMADataPoint, 0, len(c.Values))
+	for i, v := range c.Values {
+		if c.IsMissing(i) {
+			continue
+		}
+
+		points = append(points, SMADataPoint{
+			Time:  c.Start.Add(time.Duration(i) * interval),
+			Value: v,
+		})
+	}
+	return points
+}
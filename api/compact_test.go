@@ -0,0 +1,57 @@
+//go:build unit
+// +build unit
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCompactDataSuite(t *testing.T) {
+	suite.Run(t, new(CompactDataSuite))
+}
+
+type CompactDataSuite struct {
+	suite.Suite
+}
+
+func (suite *CompactDataSuite) TestNewCompactData() {
+	data := []SMADataPoint{
+		{Time: time.Unix(0, 0), Value: 1},
+		{Time: time.Unix(120, 0), Value: 3},
+		{Time: time.Unix(150, 0), Value: 42}, // Not aligned
+		{Time: time.Unix(600, 0), Value: 42}, // Out of range
+	}
+
+	c := NewCompactData(time.Unix(0, 0), time.Unix(540, 0), period.M1, data)
+
+	suite.Require().Equal([]float64{1, 0, 3, 0, 0, 0, 0, 0, 0, 0}, c.Values)
+	suite.Require().Equal([]byte{0xFA, 0x03}, c.Missing)
+	suite.Require().False(c.IsMissing(0))
+	suite.Require().True(c.IsMissing(1))
+	suite.Require().True(c.IsMissing(10))
+	suite.Require().Equal(data[:2], c.Points())
+}
+
+func (suite *CompactDataSuite) TestFormatResults() {
+	params := ListWorkflowParams{
+		Period: period.M1,
+		Start:  time.Unix(0, 0),
+		End:    time.Unix(120, 0),
+	}
+	data := []SMADataPoint{
+		{Time: time.Unix(0, 0), Value: 1},
+		{Time: time.Unix(120, 0), Value: 3},
+	}
+
+	suite.Require().Equal(ListWorkflowResults{Data: data}, params.FormatResults(data))
+
+	params.Format = ResultFormatCompact
+	res := params.FormatResults(data)
+	suite.Require().Nil(res.Data)
+	suite.Require().Equal(data, res.Compact.Points())
+}
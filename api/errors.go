@@ -0,0 +1,28 @@
+package api
+
+import "errors"
+
+// Error types of the application errors returned by the workflows. They are
+// stable and can be used by callers to react to the errors.
+const (
+	// ErrTypeInvalidArgument is the type of errors due to invalid parameters.
+	ErrTypeInvalidArgument = "InvalidArgument"
+	// ErrTypeNotFound is the type of errors due to missing data.
+	ErrTypeNotFound = "NotFound"
+	// ErrTypeUpstreamUnavailable is the type of errors due to an unavailable
+	// upstream service (e.g. candlesticks service).
+	ErrTypeUpstreamUnavailable = "UpstreamUnavailable"
+	// ErrTypeAlreadyExists is the type of errors due to an existing resource
+	// that is not replaced (e.g. export file).
+	ErrTypeAlreadyExists = "AlreadyExists"
+)
+
+var (
+	// ErrUnexpectedProtoMessage is returned when a protobuf message does not
+	// correspond to the payload it is loaded into.
+	ErrUnexpectedProtoMessage = errors.New("unexpected protobuf message")
+	// ErrUnknownResultFormat is returned when the result format is not supported.
+	ErrUnknownResultFormat = errors.New("unknown result format")
+	// ErrUnknownExportFormat is returned when the export format is not supported.
+	ErrUnknownExportFormat = errors.New("unknown export format")
+)
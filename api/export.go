@@ -0,0 +1,28 @@
+package api
+
+import "fmt"
+
+// ExportFormat is the file format of an export.
+type ExportFormat string
+
+const (
+	// ExportFormatCSV exports the series as CSV, with a header line.
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatParquet exports the series as Parquet.
+	ExportFormatParquet ExportFormat = "parquet"
+)
+
+// Validate checks that the export format is supported.
+func (f ExportFormat) Validate() error {
+	switch f {
+	case ExportFormatCSV, ExportFormatParquet:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownExportFormat, string(f))
+	}
+}
+
+// Extension returns the file extension of the export format.
+func (f ExportFormat) Extension() string {
+	return "." + string(f)
+}
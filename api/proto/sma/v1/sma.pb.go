@@ -0,0 +1,1500 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: sma/v1/sma.proto
+
+package smav1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListWorkflowParams is the parameters of the List workflow.
+type ListWorkflowParams struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Exchange     string                 `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair         string                 `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Period       string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	Start        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
+	End          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
+	PeriodNumber int64                  `protobuf:"varint,6,opt,name=period_number,json=periodNumber,proto3" json:"period_number,omitempty"`
+	PriceType    string                 `protobuf:"bytes,7,opt,name=price_type,json=priceType,proto3" json:"price_type,omitempty"`
+	// Format is the shape of the results (points by default).
+	Format        string `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowParams) Reset() {
+	*x = ListWorkflowParams{}
+	mi := &file_sma_v1_sma_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowParams) ProtoMessage() {}
+
+func (x *ListWorkflowParams) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowParams.ProtoReflect.Descriptor instead.
+func (*ListWorkflowParams) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListWorkflowParams) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *ListWorkflowParams) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *ListWorkflowParams) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *ListWorkflowParams) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ListWorkflowParams) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *ListWorkflowParams) GetPeriodNumber() int64 {
+	if x != nil {
+		return x.PeriodNumber
+	}
+	return 0
+}
+
+func (x *ListWorkflowParams) GetPriceType() string {
+	if x != nil {
+		return x.PriceType
+	}
+	return ""
+}
+
+func (x *ListWorkflowParams) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// SMADataPoint represents a single SMA data point with its time and value.
+type SMADataPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SMADataPoint) Reset() {
+	*x = SMADataPoint{}
+	mi := &file_sma_v1_sma_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SMADataPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMADataPoint) ProtoMessage() {}
+
+func (x *SMADataPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMADataPoint.ProtoReflect.Descriptor instead.
+func (*SMADataPoint) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SMADataPoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SMADataPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// CompactData is a columnar representation of a regular SMA time serie.
+type CompactData struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Start  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Period string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	Values []float64              `protobuf:"fixed64,3,rep,packed,name=values,proto3" json:"values,omitempty"`
+	// Missing is a bitmap where the bit i (least significant bit first) is
+	// set when there is no value at index i.
+	Missing       []byte `protobuf:"bytes,4,opt,name=missing,proto3" json:"missing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompactData) Reset() {
+	*x = CompactData{}
+	mi := &file_sma_v1_sma_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompactData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactData) ProtoMessage() {}
+
+func (x *CompactData) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactData.ProtoReflect.Descriptor instead.
+func (*CompactData) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CompactData) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *CompactData) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *CompactData) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *CompactData) GetMissing() []byte {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+// ListWorkflowResults is the result of the List workflow.
+type ListWorkflowResults struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*SMADataPoint        `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Compact       *CompactData           `protobuf:"bytes,2,opt,name=compact,proto3" json:"compact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowResults) Reset() {
+	*x = ListWorkflowResults{}
+	mi := &file_sma_v1_sma_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowResults) ProtoMessage() {}
+
+func (x *ListWorkflowResults) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowResults.ProtoReflect.Descriptor instead.
+func (*ListWorkflowResults) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListWorkflowResults) GetData() []*SMADataPoint {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListWorkflowResults) GetCompact() *CompactData {
+	if x != nil {
+		return x.Compact
+	}
+	return nil
+}
+
+// InvalidateWorkflowParams is the parameters of the Invalidate workflow.
+type InvalidateWorkflowParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exchange      string                 `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair          string                 `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Period        string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateWorkflowParams) Reset() {
+	*x = InvalidateWorkflowParams{}
+	mi := &file_sma_v1_sma_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateWorkflowParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateWorkflowParams) ProtoMessage() {}
+
+func (x *InvalidateWorkflowParams) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateWorkflowParams.ProtoReflect.Descriptor instead.
+func (*InvalidateWorkflowParams) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InvalidateWorkflowParams) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *InvalidateWorkflowParams) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *InvalidateWorkflowParams) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *InvalidateWorkflowParams) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *InvalidateWorkflowParams) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+// InvalidateWorkflowResults is the result of the Invalidate workflow.
+type InvalidateWorkflowResults struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	InvalidatedCount int64                  `protobuf:"varint,1,opt,name=invalidated_count,json=invalidatedCount,proto3" json:"invalidated_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *InvalidateWorkflowResults) Reset() {
+	*x = InvalidateWorkflowResults{}
+	mi := &file_sma_v1_sma_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateWorkflowResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateWorkflowResults) ProtoMessage() {}
+
+func (x *InvalidateWorkflowResults) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateWorkflowResults.ProtoReflect.Descriptor instead.
+func (*InvalidateWorkflowResults) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InvalidateWorkflowResults) GetInvalidatedCount() int64 {
+	if x != nil {
+		return x.InvalidatedCount
+	}
+	return 0
+}
+
+// ListSeriesWorkflowParams is the parameters of the ListSeries workflow.
+type ListSeriesWorkflowParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeriesWorkflowParams) Reset() {
+	*x = ListSeriesWorkflowParams{}
+	mi := &file_sma_v1_sma_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeriesWorkflowParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeriesWorkflowParams) ProtoMessage() {}
+
+func (x *ListSeriesWorkflowParams) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeriesWorkflowParams.ProtoReflect.Descriptor instead.
+func (*ListSeriesWorkflowParams) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{6}
+}
+
+// SeriesInfo describes a cached SMA series.
+type SeriesInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exchange      string                 `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair          string                 `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Period        string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	PeriodNumber  int64                  `protobuf:"varint,4,opt,name=period_number,json=periodNumber,proto3" json:"period_number,omitempty"`
+	PriceType     string                 `protobuf:"bytes,5,opt,name=price_type,json=priceType,proto3" json:"price_type,omitempty"`
+	First         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=first,proto3" json:"first,omitempty"`
+	Last          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last,proto3" json:"last,omitempty"`
+	Count         int64                  `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`
+	GapCount      int64                  `protobuf:"varint,9,opt,name=gap_count,json=gapCount,proto3" json:"gap_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesInfo) Reset() {
+	*x = SeriesInfo{}
+	mi := &file_sma_v1_sma_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesInfo) ProtoMessage() {}
+
+func (x *SeriesInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesInfo.ProtoReflect.Descriptor instead.
+func (*SeriesInfo) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SeriesInfo) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *SeriesInfo) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *SeriesInfo) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *SeriesInfo) GetPeriodNumber() int64 {
+	if x != nil {
+		return x.PeriodNumber
+	}
+	return 0
+}
+
+func (x *SeriesInfo) GetPriceType() string {
+	if x != nil {
+		return x.PriceType
+	}
+	return ""
+}
+
+func (x *SeriesInfo) GetFirst() *timestamppb.Timestamp {
+	if x != nil {
+		return x.First
+	}
+	return nil
+}
+
+func (x *SeriesInfo) GetLast() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Last
+	}
+	return nil
+}
+
+func (x *SeriesInfo) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SeriesInfo) GetGapCount() int64 {
+	if x != nil {
+		return x.GapCount
+	}
+	return 0
+}
+
+// ListSeriesWorkflowResults is the result of the ListSeries workflow.
+type ListSeriesWorkflowResults struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        []*SeriesInfo          `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeriesWorkflowResults) Reset() {
+	*x = ListSeriesWorkflowResults{}
+	mi := &file_sma_v1_sma_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeriesWorkflowResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeriesWorkflowResults) ProtoMessage() {}
+
+func (x *ListSeriesWorkflowResults) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeriesWorkflowResults.ProtoReflect.Descriptor instead.
+func (*ListSeriesWorkflowResults) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListSeriesWorkflowResults) GetSeries() []*SeriesInfo {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+// ExportWorkflowParams is the parameters of the Export workflow.
+// Empty filters select every series and unset times do not bound the range.
+type ExportWorkflowParams struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Exchange     string                 `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair         string                 `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Period       string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	PeriodNumber int64                  `protobuf:"varint,4,opt,name=period_number,json=periodNumber,proto3" json:"period_number,omitempty"`
+	PriceType    string                 `protobuf:"bytes,5,opt,name=price_type,json=priceType,proto3" json:"price_type,omitempty"`
+	Start        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start,proto3" json:"start,omitempty"`
+	End          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end,proto3" json:"end,omitempty"`
+	Format       string                 `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"`
+	// FileName is the name of the created file, relative to the export
+	// directory of the worker.
+	FileName string `protobuf:"bytes,9,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// Overwrite replaces the file if it already exists.
+	Overwrite     bool `protobuf:"varint,10,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportWorkflowParams) Reset() {
+	*x = ExportWorkflowParams{}
+	mi := &file_sma_v1_sma_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportWorkflowParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportWorkflowParams) ProtoMessage() {}
+
+func (x *ExportWorkflowParams) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportWorkflowParams.ProtoReflect.Descriptor instead.
+func (*ExportWorkflowParams) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportWorkflowParams) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *ExportWorkflowParams) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *ExportWorkflowParams) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *ExportWorkflowParams) GetPeriodNumber() int64 {
+	if x != nil {
+		return x.PeriodNumber
+	}
+	return 0
+}
+
+func (x *ExportWorkflowParams) GetPriceType() string {
+	if x != nil {
+		return x.PriceType
+	}
+	return ""
+}
+
+func (x *ExportWorkflowParams) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ExportWorkflowParams) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *ExportWorkflowParams) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportWorkflowParams) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportWorkflowParams) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+// ExportWorkflowResults is the result of the Export workflow.
+type ExportWorkflowResults struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path is the path of the created file, relative to the export directory
+	// of the worker.
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Count         int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportWorkflowResults) Reset() {
+	*x = ExportWorkflowResults{}
+	mi := &file_sma_v1_sma_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportWorkflowResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportWorkflowResults) ProtoMessage() {}
+
+func (x *ExportWorkflowResults) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportWorkflowResults.ProtoReflect.Descriptor instead.
+func (*ExportWorkflowResults) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportWorkflowResults) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExportWorkflowResults) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// VerifyWorkflowParams is the parameters of the Verify workflow.
+type VerifyWorkflowParams struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Exchange     string                 `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair         string                 `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Period       string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	PeriodNumber int64                  `protobuf:"varint,4,opt,name=period_number,json=periodNumber,proto3" json:"period_number,omitempty"`
+	PriceType    string                 `protobuf:"bytes,5,opt,name=price_type,json=priceType,proto3" json:"price_type,omitempty"`
+	Start        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start,proto3" json:"start,omitempty"`
+	End          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end,proto3" json:"end,omitempty"`
+	// Tolerance is the maximum absolute difference between a cached and a
+	// recomputed point before it is reported.
+	Tolerance float64 `protobuf:"fixed64,8,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+	// SampleSize is the count of cached points, evenly spread on the range,
+	// that are verified. All points are verified if it is 0.
+	SampleSize int64 `protobuf:"varint,9,opt,name=sample_size,json=sampleSize,proto3" json:"sample_size,omitempty"`
+	// Repair replaces the mismatching cached points by the recomputed ones.
+	Repair        bool `protobuf:"varint,10,opt,name=repair,proto3" json:"repair,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyWorkflowParams) Reset() {
+	*x = VerifyWorkflowParams{}
+	mi := &file_sma_v1_sma_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyWorkflowParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyWorkflowParams) ProtoMessage() {}
+
+func (x *VerifyWorkflowParams) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyWorkflowParams.ProtoReflect.Descriptor instead.
+func (*VerifyWorkflowParams) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VerifyWorkflowParams) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *VerifyWorkflowParams) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *VerifyWorkflowParams) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *VerifyWorkflowParams) GetPeriodNumber() int64 {
+	if x != nil {
+		return x.PeriodNumber
+	}
+	return 0
+}
+
+func (x *VerifyWorkflowParams) GetPriceType() string {
+	if x != nil {
+		return x.PriceType
+	}
+	return ""
+}
+
+func (x *VerifyWorkflowParams) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *VerifyWorkflowParams) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *VerifyWorkflowParams) GetTolerance() float64 {
+	if x != nil {
+		return x.Tolerance
+	}
+	return 0
+}
+
+func (x *VerifyWorkflowParams) GetSampleSize() int64 {
+	if x != nil {
+		return x.SampleSize
+	}
+	return 0
+}
+
+func (x *VerifyWorkflowParams) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+// VerifyMismatch is a cached point that differs from the recomputed one.
+type VerifyMismatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Cached        float64                `protobuf:"fixed64,2,opt,name=cached,proto3" json:"cached,omitempty"`
+	Computed      float64                `protobuf:"fixed64,3,opt,name=computed,proto3" json:"computed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMismatch) Reset() {
+	*x = VerifyMismatch{}
+	mi := &file_sma_v1_sma_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMismatch) ProtoMessage() {}
+
+func (x *VerifyMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMismatch.ProtoReflect.Descriptor instead.
+func (*VerifyMismatch) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VerifyMismatch) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *VerifyMismatch) GetCached() float64 {
+	if x != nil {
+		return x.Cached
+	}
+	return 0
+}
+
+func (x *VerifyMismatch) GetComputed() float64 {
+	if x != nil {
+		return x.Computed
+	}
+	return 0
+}
+
+// VerifyWorkflowResults is the result of the Verify workflow.
+type VerifyWorkflowResults struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Checked    int64                  `protobuf:"varint,1,opt,name=checked,proto3" json:"checked,omitempty"`
+	Mismatches []*VerifyMismatch      `protobuf:"bytes,2,rep,name=mismatches,proto3" json:"mismatches,omitempty"`
+	Repaired   int64                  `protobuf:"varint,3,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	// Times of the cached points that could not be recomputed, not counted in
+	// checked.
+	Unverifiable  []*timestamppb.Timestamp `protobuf:"bytes,4,rep,name=unverifiable,proto3" json:"unverifiable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyWorkflowResults) Reset() {
+	*x = VerifyWorkflowResults{}
+	mi := &file_sma_v1_sma_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyWorkflowResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyWorkflowResults) ProtoMessage() {}
+
+func (x *VerifyWorkflowResults) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyWorkflowResults.ProtoReflect.Descriptor instead.
+func (*VerifyWorkflowResults) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VerifyWorkflowResults) GetChecked() int64 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *VerifyWorkflowResults) GetMismatches() []*VerifyMismatch {
+	if x != nil {
+		return x.Mismatches
+	}
+	return nil
+}
+
+func (x *VerifyWorkflowResults) GetRepaired() int64 {
+	if x != nil {
+		return x.Repaired
+	}
+	return 0
+}
+
+func (x *VerifyWorkflowResults) GetUnverifiable() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.Unverifiable
+	}
+	return nil
+}
+
+// ServiceInfoParams contains the parameters of the service info workflow.
+type ServiceInfoParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceInfoParams) Reset() {
+	*x = ServiceInfoParams{}
+	mi := &file_sma_v1_sma_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceInfoParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceInfoParams) ProtoMessage() {}
+
+func (x *ServiceInfoParams) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceInfoParams.ProtoReflect.Descriptor instead.
+func (*ServiceInfoParams) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{14}
+}
+
+// ServiceInfoResults contains the result of the service info workflow.
+type ServiceInfoResults struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceInfoResults) Reset() {
+	*x = ServiceInfoResults{}
+	mi := &file_sma_v1_sma_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceInfoResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceInfoResults) ProtoMessage() {}
+
+func (x *ServiceInfoResults) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceInfoResults.ProtoReflect.Descriptor instead.
+func (*ServiceInfoResults) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ServiceInfoResults) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// WatchSMARequest is the request to watch the SMA points of a series.
+type WatchSMARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exchange      string                 `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair          string                 `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Period        string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	PeriodNumber  int64                  `protobuf:"varint,4,opt,name=period_number,json=periodNumber,proto3" json:"period_number,omitempty"`
+	PriceType     string                 `protobuf:"bytes,5,opt,name=price_type,json=priceType,proto3" json:"price_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSMARequest) Reset() {
+	*x = WatchSMARequest{}
+	mi := &file_sma_v1_sma_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSMARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSMARequest) ProtoMessage() {}
+
+func (x *WatchSMARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sma_v1_sma_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSMARequest.ProtoReflect.Descriptor instead.
+func (*WatchSMARequest) Descriptor() ([]byte, []int) {
+	return file_sma_v1_sma_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WatchSMARequest) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *WatchSMARequest) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *WatchSMARequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *WatchSMARequest) GetPeriodNumber() int64 {
+	if x != nil {
+		return x.PeriodNumber
+	}
+	return 0
+}
+
+func (x *WatchSMARequest) GetPriceType() string {
+	if x != nil {
+		return x.PriceType
+	}
+	return ""
+}
+
+var File_sma_v1_sma_proto protoreflect.FileDescriptor
+
+var file_sma_v1_sma_proto_rawDesc = string([]byte{
+	0x0a, 0x10, 0x73, 0x6d, 0x61, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x6d, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x14, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x98, 0x02, 0x0a, 0x12, 0x4c, 0x69,
+	0x73, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x22, 0x54, 0x0a, 0x0c, 0x53, 0x4d, 0x41, 0x44, 0x61, 0x74, 0x61, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x89, 0x01, 0x0a, 0x0b, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x22, 0x8a, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x57,
+	0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x36,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x73, 0x6d, 0x61,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x4d, 0x41, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65,
+	0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x44, 0x61, 0x74, 0x61, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x63, 0x74, 0x22, 0xc2, 0x01, 0x0a, 0x18, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x48, 0x0a, 0x19, 0x49, 0x6e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x1a, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0xad,
+	0x02, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a,
+	0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x6c,
+	0x61, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x67, 0x61, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x55,
+	0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x57, 0x6f, 0x72, 0x6b,
+	0x66, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x38, 0x0a, 0x06, 0x73,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x73,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0xd5, 0x02, 0x0a, 0x14, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03,
+	0x65, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x22, 0x41, 0x0a,
+	0x15, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0xd9, 0x02, 0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x57, 0x6f, 0x72, 0x6b, 0x66,
+	0x6c, 0x6f, 0x77, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x22, 0x74, 0x0a, 0x0e,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2e,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x64, 0x22, 0xd3, 0x01, 0x0a, 0x15, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x57, 0x6f, 0x72,
+	0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x44, 0x0a, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x12, 0x3e, 0x0a, 0x0c, 0x75, 0x6e, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x75, 0x6e, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x2e, 0x0a,
+	0x12, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x9d, 0x01,
+	0x0a, 0x0f, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x4d, 0x41, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x32, 0x8d, 0x03,
+	0x0a, 0x0a, 0x53, 0x4d, 0x41, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x62, 0x0a, 0x07,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x4d, 0x41, 0x12, 0x28, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65,
+	0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x1a, 0x29, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x6f, 0x72,
+	0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x28, 0x00, 0x30, 0x00,
+	0x12, 0x61, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x4d,
+	0x41, 0x12, 0x28, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x6f, 0x72,
+	0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x22, 0x2e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x4d, 0x41, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x28,
+	0x00, 0x30, 0x01, 0x12, 0x59, 0x0a, 0x08, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x4d, 0x41, 0x12,
+	0x25, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x73, 0x6d, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x4d, 0x41, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x6c,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x4d,
+	0x41, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x28, 0x00, 0x30, 0x01, 0x12, 0x5d,
+	0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x27, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x6c,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x73, 0x6d, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a,
+	0x28, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x73, 0x6d, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x28, 0x00, 0x30, 0x00, 0x42, 0x35, 0x5a,
+	0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x73, 0x6d, 0x61, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x6d, 0x61, 0x2f, 0x76, 0x31, 0x3b, 0x73,
+	0x6d, 0x61, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_sma_v1_sma_proto_rawDescOnce sync.Once
+	file_sma_v1_sma_proto_rawDescData []byte
+)
+
+func file_sma_v1_sma_proto_rawDescGZIP() []byte {
+	file_sma_v1_sma_proto_rawDescOnce.Do(func() {
+		file_sma_v1_sma_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sma_v1_sma_proto_rawDesc), len(file_sma_v1_sma_proto_rawDesc)))
+	})
+	return file_sma_v1_sma_proto_rawDescData
+}
+
+var file_sma_v1_sma_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_sma_v1_sma_proto_goTypes = []any{
+	(*ListWorkflowParams)(nil),        // 0: cryptellation.sma.v1.ListWorkflowParams
+	(*SMADataPoint)(nil),              // 1: cryptellation.sma.v1.SMADataPoint
+	(*CompactData)(nil),               // 2: cryptellation.sma.v1.CompactData
+	(*ListWorkflowResults)(nil),       // 3: cryptellation.sma.v1.ListWorkflowResults
+	(*InvalidateWorkflowParams)(nil),  // 4: cryptellation.sma.v1.InvalidateWorkflowParams
+	(*InvalidateWorkflowResults)(nil), // 5: cryptellation.sma.v1.InvalidateWorkflowResults
+	(*ListSeriesWorkflowParams)(nil),  // 6: cryptellation.sma.v1.ListSeriesWorkflowParams
+	(*SeriesInfo)(nil),                // 7: cryptellation.sma.v1.SeriesInfo
+	(*ListSeriesWorkflowResults)(nil), // 8: cryptellation.sma.v1.ListSeriesWorkflowResults
+	(*ExportWorkflowParams)(nil),      // 9: cryptellation.sma.v1.ExportWorkflowParams
+	(*ExportWorkflowResults)(nil),     // 10: cryptellation.sma.v1.ExportWorkflowResults
+	(*VerifyWorkflowParams)(nil),      // 11: cryptellation.sma.v1.VerifyWorkflowParams
+	(*VerifyMismatch)(nil),            // 12: cryptellation.sma.v1.VerifyMismatch
+	(*VerifyWorkflowResults)(nil),     // 13: cryptellation.sma.v1.VerifyWorkflowResults
+	(*ServiceInfoParams)(nil),         // 14: cryptellation.sma.v1.ServiceInfoParams
+	(*ServiceInfoResults)(nil),        // 15: cryptellation.sma.v1.ServiceInfoResults
+	(*WatchSMARequest)(nil),           // 16: cryptellation.sma.v1.WatchSMARequest
+	(*timestamppb.Timestamp)(nil),     // 17: google.protobuf.Timestamp
+}
+var file_sma_v1_sma_proto_depIdxs = []int32{
+	17, // 0: cryptellation.sma.v1.ListWorkflowParams.start:type_name -> google.protobuf.Timestamp
+	17, // 1: cryptellation.sma.v1.ListWorkflowParams.end:type_name -> google.protobuf.Timestamp
+	17, // 2: cryptellation.sma.v1.SMADataPoint.time:type_name -> google.protobuf.Timestamp
+	17, // 3: cryptellation.sma.v1.CompactData.start:type_name -> google.protobuf.Timestamp
+	1,  // 4: cryptellation.sma.v1.ListWorkflowResults.data:type_name -> cryptellation.sma.v1.SMADataPoint
+	2,  // 5: cryptellation.sma.v1.ListWorkflowResults.compact:type_name -> cryptellation.sma.v1.CompactData
+	17, // 6: cryptellation.sma.v1.InvalidateWorkflowParams.start:type_name -> google.protobuf.Timestamp
+	17, // 7: cryptellation.sma.v1.InvalidateWorkflowParams.end:type_name -> google.protobuf.Timestamp
+	17, // 8: cryptellation.sma.v1.SeriesInfo.first:type_name -> google.protobuf.Timestamp
+	17, // 9: cryptellation.sma.v1.SeriesInfo.last:type_name -> google.protobuf.Timestamp
+	7,  // 10: cryptellation.sma.v1.ListSeriesWorkflowResults.series:type_name -> cryptellation.sma.v1.SeriesInfo
+	17, // 11: cryptellation.sma.v1.ExportWorkflowParams.start:type_name -> google.protobuf.Timestamp
+	17, // 12: cryptellation.sma.v1.ExportWorkflowParams.end:type_name -> google.protobuf.Timestamp
+	17, // 13: cryptellation.sma.v1.VerifyWorkflowParams.start:type_name -> google.protobuf.Timestamp
+	17, // 14: cryptellation.sma.v1.VerifyWorkflowParams.end:type_name -> google.protobuf.Timestamp
+	17, // 15: cryptellation.sma.v1.VerifyMismatch.time:type_name -> google.protobuf.Timestamp
+	12, // 16: cryptellation.sma.v1.VerifyWorkflowResults.mismatches:type_name -> cryptellation.sma.v1.VerifyMismatch
+	17, // 17: cryptellation.sma.v1.VerifyWorkflowResults.unverifiable:type_name -> google.protobuf.Timestamp
+	0,  // 18: cryptellation.sma.v1.SMAService.ListSMA:input_type -> cryptellation.sma.v1.ListWorkflowParams
+	0,  // 19: cryptellation.sma.v1.SMAService.StreamListSMA:input_type -> cryptellation.sma.v1.ListWorkflowParams
+	16, // 20: cryptellation.sma.v1.SMAService.WatchSMA:input_type -> cryptellation.sma.v1.WatchSMARequest
+	14, // 21: cryptellation.sma.v1.SMAService.Info:input_type -> cryptellation.sma.v1.ServiceInfoParams
+	3,  // 22: cryptellation.sma.v1.SMAService.ListSMA:output_type -> cryptellation.sma.v1.ListWorkflowResults
+	1,  // 23: cryptellation.sma.v1.SMAService.StreamListSMA:output_type -> cryptellation.sma.v1.SMADataPoint
+	1,  // 24: cryptellation.sma.v1.SMAService.WatchSMA:output_type -> cryptellation.sma.v1.SMADataPoint
+	15, // 25: cryptellation.sma.v1.SMAService.Info:output_type -> cryptellation.sma.v1.ServiceInfoResults
+	22, // [22:26] is the sub-list for method output_type
+	18, // [18:22] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_sma_v1_sma_proto_init() }
+func file_sma_v1_sma_proto_init() {
+	if File_sma_v1_sma_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sma_v1_sma_proto_rawDesc), len(file_sma_v1_sma_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sma_v1_sma_proto_goTypes,
+		DependencyIndexes: file_sma_v1_sma_proto_depIdxs,
+		MessageInfos:      file_sma_v1_sma_proto_msgTypes,
+	}.Build()
+	File_sma_v1_sma_proto = out.File
+	file_sma_v1_sma_proto_goTypes = nil
+	file_sma_v1_sma_proto_depIdxs = nil
+}
@@ -0,0 +1,254 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: sma/v1/sma.proto
+
+package smav1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SMAService_ListSMA_FullMethodName       = "/cryptellation.sma.v1.SMAService/ListSMA"
+	SMAService_StreamListSMA_FullMethodName = "/cryptellation.sma.v1.SMAService/StreamListSMA"
+	SMAService_WatchSMA_FullMethodName      = "/cryptellation.sma.v1.SMAService/WatchSMA"
+	SMAService_Info_FullMethodName          = "/cryptellation.sma.v1.SMAService/Info"
+)
+
+// SMAServiceClient is the client API for SMAService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SMAService exposes the SMA workflows over gRPC.
+type SMAServiceClient interface {
+	// ListSMA returns the SMA points of a range.
+	ListSMA(ctx context.Context, in *ListWorkflowParams, opts ...grpc.CallOption) (*ListWorkflowResults, error)
+	// StreamListSMA returns the SMA points of a range as a stream, for large ranges.
+	StreamListSMA(ctx context.Context, in *ListWorkflowParams, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SMADataPoint], error)
+	// WatchSMA streams the SMA point of each period once it is closed.
+	WatchSMA(ctx context.Context, in *WatchSMARequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SMADataPoint], error)
+	// Info returns the service information.
+	Info(ctx context.Context, in *ServiceInfoParams, opts ...grpc.CallOption) (*ServiceInfoResults, error)
+}
+
+type sMAServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSMAServiceClient(cc grpc.ClientConnInterface) SMAServiceClient {
+	return &sMAServiceClient{cc}
+}
+
+func (c *sMAServiceClient) ListSMA(ctx context.Context, in *ListWorkflowParams, opts ...grpc.CallOption) (*ListWorkflowResults, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkflowResults)
+	err := c.cc.Invoke(ctx, SMAService_ListSMA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sMAServiceClient) StreamListSMA(ctx context.Context, in *ListWorkflowParams, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SMADataPoint], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SMAService_ServiceDesc.Streams[0], SMAService_StreamListSMA_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListWorkflowParams, SMADataPoint]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SMAService_StreamListSMAClient = grpc.ServerStreamingClient[SMADataPoint]
+
+func (c *sMAServiceClient) WatchSMA(ctx context.Context, in *WatchSMARequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SMADataPoint], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SMAService_ServiceDesc.Streams[1], SMAService_WatchSMA_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchSMARequest, SMADataPoint]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SMAService_WatchSMAClient = grpc.ServerStreamingClient[SMADataPoint]
+
+func (c *sMAServiceClient) Info(ctx context.Context, in *ServiceInfoParams, opts ...grpc.CallOption) (*ServiceInfoResults, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceInfoResults)
+	err := c.cc.Invoke(ctx, SMAService_Info_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SMAServiceServer is the server API for SMAService service.
+// All implementations must embed UnimplementedSMAServiceServer
+// for forward compatibility.
+//
+// SMAService exposes the SMA workflows over gRPC.
+type SMAServiceServer interface {
+	// ListSMA returns the SMA points of a range.
+	ListSMA(context.Context, *ListWorkflowParams) (*ListWorkflowResults, error)
+	// StreamListSMA returns the SMA points of a range as a stream, for large ranges.
+	StreamListSMA(*ListWorkflowParams, grpc.ServerStreamingServer[SMADataPoint]) error
+	// WatchSMA streams the SMA point of each period once it is closed.
+	WatchSMA(*WatchSMARequest, grpc.ServerStreamingServer[SMADataPoint]) error
+	// Info returns the service information.
+	Info(context.Context, *ServiceInfoParams) (*ServiceInfoResults, error)
+	mustEmbedUnimplementedSMAServiceServer()
+}
+
+// UnimplementedSMAServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSMAServiceServer struct{}
+
+func (UnimplementedSMAServiceServer) ListSMA(context.Context, *ListWorkflowParams) (*ListWorkflowResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSMA not implemented")
+}
+func (UnimplementedSMAServiceServer) StreamListSMA(*ListWorkflowParams, grpc.ServerStreamingServer[SMADataPoint]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamListSMA not implemented")
+}
+func (UnimplementedSMAServiceServer) WatchSMA(*WatchSMARequest, grpc.ServerStreamingServer[SMADataPoint]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSMA not implemented")
+}
+func (UnimplementedSMAServiceServer) Info(context.Context, *ServiceInfoParams) (*ServiceInfoResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedSMAServiceServer) mustEmbedUnimplementedSMAServiceServer() {}
+func (UnimplementedSMAServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeSMAServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SMAServiceServer will
+// result in compilation errors.
+type UnsafeSMAServiceServer interface {
+	mustEmbedUnimplementedSMAServiceServer()
+}
+
+func RegisterSMAServiceServer(s grpc.ServiceRegistrar, srv SMAServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSMAServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SMAService_ServiceDesc, srv)
+}
+
+func _SMAService_ListSMA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkflowParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMAServiceServer).ListSMA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMAService_ListSMA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMAServiceServer).ListSMA(ctx, req.(*ListWorkflowParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SMAService_StreamListSMA_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListWorkflowParams)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SMAServiceServer).StreamListSMA(m, &grpc.GenericServerStream[ListWorkflowParams, SMADataPoint]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SMAService_StreamListSMAServer = grpc.ServerStreamingServer[SMADataPoint]
+
+func _SMAService_WatchSMA_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSMARequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SMAServiceServer).WatchSMA(m, &grpc.GenericServerStream[WatchSMARequest, SMADataPoint]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SMAService_WatchSMAServer = grpc.ServerStreamingServer[SMADataPoint]
+
+func _SMAService_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceInfoParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SMAServiceServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SMAService_Info_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SMAServiceServer).Info(ctx, req.(*ServiceInfoParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SMAService_ServiceDesc is the grpc.ServiceDesc for SMAService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SMAService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cryptellation.sma.v1.SMAService",
+	HandlerType: (*SMAServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSMA",
+			Handler:    _SMAService_ListSMA_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _SMAService_Info_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamListSMA",
+			Handler:       _SMAService_StreamListSMA_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchSMA",
+			Handler:       _SMAService_WatchSMA_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sma/v1/sma.proto",
+}
@@ -0,0 +1,451 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	smav1 "github.com/cryptellation/sma/api/proto/sma/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The protobuf messages and gRPC service are defined in proto/sma/v1/sma.proto
+// and the Go code is generated with:
+//
+//	protoc -I proto --go_out=proto --go_opt=paths=source_relative \
+//		--go-grpc_out=proto --go-grpc_opt=paths=source_relative sma/v1/sma.proto
+
+// ProtoConvertible is implemented by the payloads that have a protobuf
+// representation.
+type ProtoConvertible interface {
+	// ToProto converts the payload into its protobuf message.
+	ToProto() proto.Message
+}
+
+// ProtoLoadable is implemented by the payloads pointers that can be loaded
+// from their protobuf representation.
+type ProtoLoadable interface {
+	ProtoConvertible
+	// FromProto loads the payload from its protobuf message.
+	FromProto(m proto.Message) error
+}
+
+// Check that the payloads implement the protobuf interfaces.
+var (
+	_ ProtoLoadable = &ListWorkflowParams{}
+	_ ProtoLoadable = &ListWorkflowResults{}
+	_ ProtoLoadable = &InvalidateWorkflowParams{}
+	_ ProtoLoadable = &InvalidateWorkflowResults{}
+	_ ProtoLoadable = &ListSeriesWorkflowParams{}
+	_ ProtoLoadable = &ListSeriesWorkflowResults{}
+	_ ProtoLoadable = &ExportWorkflowParams{}
+	_ ProtoLoadable = &ExportWorkflowResults{}
+	_ ProtoLoadable = &VerifyWorkflowParams{}
+	_ ProtoLoadable = &VerifyWorkflowResults{}
+	_ ProtoLoadable = &ServiceInfoParams{}
+	_ ProtoLoadable = &ServiceInfoResults{}
+)
+
+// ToProto converts the payload into its protobuf message.
+func (p ListWorkflowParams) ToProto() proto.Message {
+	return &smav1.ListWorkflowParams{
+		Exchange:     p.Exchange,
+		Pair:         p.Pair,
+		Period:       p.Period.String(),
+		Start:        timestamppb.New(p.Start),
+		End:          timestamppb.New(p.End),
+		PeriodNumber: int64(p.PeriodNumber),
+		PriceType:    p.PriceType.String(),
+		Format:       string(p.Format),
+	}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (p *ListWorkflowParams) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.ListWorkflowParams)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*p = ListWorkflowParams{
+		Exchange:     pm.GetExchange(),
+		Pair:         pm.GetPair(),
+		Period:       period.Symbol(pm.GetPeriod()),
+		Start:        timeFromProto(pm.GetStart()),
+		End:          timeFromProto(pm.GetEnd()),
+		PeriodNumber: int(pm.GetPeriodNumber()),
+		PriceType:    candlestick.PriceType(pm.GetPriceType()),
+		Format:       ResultFormat(pm.GetFormat()),
+	}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (r ListWorkflowResults) ToProto() proto.Message {
+	data := make([]*smav1.SMADataPoint, 0, len(r.Data))
+	for _, d := range r.Data {
+		data = append(data, &smav1.SMADataPoint{
+			Time:  timestamppb.New(d.Time),
+			Value: d.Value,
+		})
+	}
+	return &smav1.ListWorkflowResults{
+		Data:    data,
+		Compact: compactDataToProto(r.Compact),
+	}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (r *ListWorkflowResults) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.ListWorkflowResults)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*r = ListWorkflowResults{
+		Compact: compactDataFromProto(pm.GetCompact()),
+	}
+	if pm.GetData() != nil {
+		r.Data = make([]SMADataPoint, 0, len(pm.GetData()))
+	}
+	for _, d := range pm.GetData() {
+		r.Data = append(r.Data, SMADataPoint{
+			Time:  timeFromProto(d.GetTime()),
+			Value: d.GetValue(),
+		})
+	}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (p InvalidateWorkflowParams) ToProto() proto.Message {
+	return &smav1.InvalidateWorkflowParams{
+		Exchange: p.Exchange,
+		Pair:     p.Pair,
+		Period:   p.Period.String(),
+		Start:    timestamppb.New(p.Start),
+		End:      timestamppb.New(p.End),
+	}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (p *InvalidateWorkflowParams) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.InvalidateWorkflowParams)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*p = InvalidateWorkflowParams{
+		Exchange: pm.GetExchange(),
+		Pair:     pm.GetPair(),
+		Period:   period.Symbol(pm.GetPeriod()),
+		Start:    timeFromProto(pm.GetStart()),
+		End:      timeFromProto(pm.GetEnd()),
+	}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (r InvalidateWorkflowResults) ToProto() proto.Message {
+	return &smav1.InvalidateWorkflowResults{
+		InvalidatedCount: int64(r.InvalidatedCount),
+	}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (r *InvalidateWorkflowResults) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.InvalidateWorkflowResults)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*r = InvalidateWorkflowResults{
+		InvalidatedCount: int(pm.GetInvalidatedCount()),
+	}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (ListSeriesWorkflowParams) ToProto() proto.Message {
+	return &smav1.ListSeriesWorkflowParams{}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (p *ListSeriesWorkflowParams) FromProto(m proto.Message) error {
+	if _, ok := m.(*smav1.ListSeriesWorkflowParams); !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*p = ListSeriesWorkflowParams{}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (r ListSeriesWorkflowResults) ToProto() proto.Message {
+	series := make([]*smav1.SeriesInfo, 0, len(r.Series))
+	for _, s := range r.Series {
+		series = append(series, &smav1.SeriesInfo{
+			Exchange:     s.Exchange,
+			Pair:         s.Pair,
+			Period:       s.Period.String(),
+			PeriodNumber: int64(s.PeriodNumber),
+			PriceType:    s.PriceType.String(),
+			First:        timestamppb.New(s.First),
+			Last:         timestamppb.New(s.Last),
+			Count:        int64(s.Count),
+			GapCount:     int64(s.GapCount),
+		})
+	}
+	return &smav1.ListSeriesWorkflowResults{Series: series}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (r *ListSeriesWorkflowResults) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.ListSeriesWorkflowResults)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*r = ListSeriesWorkflowResults{}
+	if pm.GetSeries() != nil {
+		r.Series = make([]SeriesInfo, 0, len(pm.GetSeries()))
+	}
+	for _, s := range pm.GetSeries() {
+		r.Series = append(r.Series, SeriesInfo{
+			Exchange:     s.GetExchange(),
+			Pair:         s.GetPair(),
+			Period:       period.Symbol(s.GetPeriod()),
+			PeriodNumber: int(s.GetPeriodNumber()),
+			PriceType:    candlestick.PriceType(s.GetPriceType()),
+			First:        timeFromProto(s.GetFirst()),
+			Last:         timeFromProto(s.GetLast()),
+			Count:        int(s.GetCount()),
+			GapCount:     int(s.GetGapCount()),
+		})
+	}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (p ExportWorkflowParams) ToProto() proto.Message {
+	m := &smav1.ExportWorkflowParams{
+		Exchange:     p.Exchange,
+		Pair:         p.Pair,
+		Period:       p.Period.String(),
+		PeriodNumber: int64(p.PeriodNumber),
+		PriceType:    p.PriceType.String(),
+		Format:       string(p.Format),
+		FileName:     p.FileName,
+		Overwrite:    p.Overwrite,
+	}
+	if !p.Start.IsZero() {
+		m.Start = timestamppb.New(p.Start)
+	}
+	if !p.End.IsZero() {
+		m.End = timestamppb.New(p.End)
+	}
+	return m
+}
+
+// FromProto loads the payload from its protobuf message.
+func (p *ExportWorkflowParams) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.ExportWorkflowParams)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*p = ExportWorkflowParams{
+		Exchange:     pm.GetExchange(),
+		Pair:         pm.GetPair(),
+		Period:       period.Symbol(pm.GetPeriod()),
+		PeriodNumber: int(pm.GetPeriodNumber()),
+		PriceType:    candlestick.PriceType(pm.GetPriceType()),
+		Start:        timeFromProto(pm.GetStart()),
+		End:          timeFromProto(pm.GetEnd()),
+		Format:       ExportFormat(pm.GetFormat()),
+		FileName:     pm.GetFileName(),
+		Overwrite:    pm.GetOverwrite(),
+	}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (r ExportWorkflowResults) ToProto() proto.Message {
+	return &smav1.ExportWorkflowResults{
+		Path:  r.Path,
+		Count: int64(r.Count),
+	}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (r *ExportWorkflowResults) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.ExportWorkflowResults)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*r = ExportWorkflowResults{
+		Path:  pm.GetPath(),
+		Count: int(pm.GetCount()),
+	}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (p VerifyWorkflowParams) ToProto() proto.Message {
+	return &smav1.VerifyWorkflowParams{
+		Exchange:     p.Exchange,
+		Pair:         p.Pair,
+		Period:       p.Period.String(),
+		PeriodNumber: int64(p.PeriodNumber),
+		PriceType:    p.PriceType.String(),
+		Start:        timestamppb.New(p.Start),
+		End:          timestamppb.New(p.End),
+		Tolerance:    p.Tolerance,
+		SampleSize:   int64(p.SampleSize),
+		Repair:       p.Repair,
+	}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (p *VerifyWorkflowParams) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.VerifyWorkflowParams)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*p = VerifyWorkflowParams{
+		Exchange:     pm.GetExchange(),
+		Pair:         pm.GetPair(),
+		Period:       period.Symbol(pm.GetPeriod()),
+		PeriodNumber: int(pm.GetPeriodNumber()),
+		PriceType:    candlestick.PriceType(pm.GetPriceType()),
+		Start:        timeFromProto(pm.GetStart()),
+		End:          timeFromProto(pm.GetEnd()),
+		Tolerance:    pm.GetTolerance(),
+		SampleSize:   int(pm.GetSampleSize()),
+		Repair:       pm.GetRepair(),
+	}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (r VerifyWorkflowResults) ToProto() proto.Message {
+	mismatches := make([]*smav1.VerifyMismatch, 0, len(r.Mismatches))
+	for _, m := range r.Mismatches {
+		mismatches = append(mismatches, &smav1.VerifyMismatch{
+			Time:     timestamppb.New(m.Time),
+			Cached:   m.Cached,
+			Computed: m.Computed,
+		})
+	}
+	unverifiable := make([]*timestamppb.Timestamp, 0, len(r.Unverifiable))
+	for _, t := range r.Unverifiable {
+		unverifiable = append(unverifiable, timestamppb.New(t))
+	}
+	return &smav1.VerifyWorkflowResults{
+		Checked:      int64(r.Checked),
+		Mismatches:   mismatches,
+		Repaired:     int64(r.Repaired),
+		Unverifiable: unverifiable,
+	}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (r *VerifyWorkflowResults) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.VerifyWorkflowResults)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*r = VerifyWorkflowResults{
+		Checked:  int(pm.GetChecked()),
+		Repaired: int(pm.GetRepaired()),
+	}
+	if pm.GetMismatches() != nil {
+		r.Mismatches = make([]VerifyMismatch, 0, len(pm.GetMismatches()))
+	}
+	for _, mm := range pm.GetMismatches() {
+		r.Mismatches = append(r.Mismatches, VerifyMismatch{
+			Time:     timeFromProto(mm.GetTime()),
+			Cached:   mm.GetCached(),
+			Computed: mm.GetComputed(),
+		})
+	}
+	for _, t := range pm.GetUnverifiable() {
+		r.Unverifiable = append(r.Unverifiable, timeFromProto(t))
+	}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (ServiceInfoParams) ToProto() proto.Message {
+	return &smav1.ServiceInfoParams{}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (p *ServiceInfoParams) FromProto(m proto.Message) error {
+	if _, ok := m.(*smav1.ServiceInfoParams); !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*p = ServiceInfoParams{}
+	return nil
+}
+
+// ToProto converts the payload into its protobuf message.
+func (r ServiceInfoResults) ToProto() proto.Message {
+	return &smav1.ServiceInfoResults{
+		Version: r.Version,
+	}
+}
+
+// FromProto loads the payload from its protobuf message.
+func (r *ServiceInfoResults) FromProto(m proto.Message) error {
+	pm, ok := m.(*smav1.ServiceInfoResults)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnexpectedProtoMessage, m)
+	}
+
+	*r = ServiceInfoResults{
+		Version: pm.GetVersion(),
+	}
+	return nil
+}
+
+func compactDataToProto(c *CompactData) *smav1.CompactData {
+	if c == nil {
+		return nil
+	}
+
+	return &smav1.CompactData{
+		Start:   timestamppb.New(c.Start),
+		Period:  c.Period.String(),
+		Values:  c.Values,
+		Missing: c.Missing,
+	}
+}
+
+func compactDataFromProto(c *smav1.CompactData) *CompactData {
+	if c == nil {
+		return nil
+	}
+
+	return &CompactData{
+		Start:   timeFromProto(c.GetStart()),
+		Period:  period.Symbol(c.GetPeriod()),
+		Values:  c.GetValues(),
+		Missing: c.GetMissing(),
+	}
+}
+
+// timeFromProto converts a protobuf timestamp into a time, keeping the zero
+// time for unset timestamps.
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	_ "embed" // Embed the JSON schema
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaVersion is the version of the wire schema of the workflows payloads.
+//
+// Payloads are encoded as JSON objects with snake_case keys, times as RFC3339
+// strings, periods as their symbol (e.g. "M1") and price types as their name
+// (e.g. "close"). Any change that renames, removes or changes the type of a
+// field must increment this version and add the corresponding schema file.
+const SchemaVersion = 1
+
+// SchemaVersionMetadataKey is the key of the Temporal payload metadata
+// carrying the schema version of the payload. Payloads without it have been
+// encoded before the version 1.
+const SchemaVersionMetadataKey = "sma-schema-version"
+
+// ErrUnsupportedSchemaVersion is returned when a payload has been encoded with
+// a schema version newer than SchemaVersion.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+
+// CheckSchemaVersion checks that a payload encoded with the given version,
+// as found in its metadata, can be decoded. An empty version corresponds to
+// a payload encoded before the version 1.
+func CheckSchemaVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+
+	v, err := strconv.Atoi(version)
+	if err != nil || v < 1 || v > SchemaVersion {
+		return fmt.Errorf("%w: %q (supported up to %d)", ErrUnsupportedSchemaVersion, version, SchemaVersion)
+	}
+	return nil
+}
+
+// SchemaV1 is the JSON schema (draft 2020-12) of the version 1 of the
+// workflows payloads, for non-Go callers.
+//
+//go:embed schema/v1.json
+var SchemaV1 []byte
+
+// unmarshalWithLegacyKeys decodes the JSON object into v, a pointer to a
+// struct, also accepting the Go field names that were used as keys before the
+// version 1 (e.g. "PeriodNumber" for "period_number"). Single word keys do not
+// need it, as the JSON decoding is case insensitive.
+//
+// It keeps the payloads of the workflows started before the version 1 readable
+// and should be removed with the version 2.
+func unmarshalWithLegacyKeys(data []byte, v any) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return json.Unmarshal(data, v)
+	}
+
+	// Rename the legacy keys, unless the current key is also set
+	renamed := false
+	t := reflect.TypeOf(v).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		raw, ok := fields[f.Name]
+		if key == "" || key == f.Name || !ok {
+			continue
+		}
+
+		if _, ok := fields[key]; !ok {
+			fields[key] = raw
+		}
+		delete(fields, f.Name)
+		renamed = true
+	}
+	if !renamed {
+		return json.Unmarshal(data, v)
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
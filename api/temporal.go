@@ -1,6 +1,7 @@
 package api
 
 import (
+	"strings"
 	"time"
 
 	"github.com/cryptellation/candlesticks/pkg/candlestick"
@@ -12,6 +13,18 @@ const (
 	WorkerTaskQueueName = "CryptellationSmaTaskQueue"
 )
 
+// TaskQueueName returns the name of the worker task queue for the given
+// deployment namespace (e.g. "staging" or "prod"), so that several SMA
+// deployments can share the same Temporal namespace. An empty namespace
+// corresponds to WorkerTaskQueueName.
+func TaskQueueName(namespace string) string {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" {
+		return WorkerTaskQueueName
+	}
+	return WorkerTaskQueueName + "-" + namespace
+}
+
 const (
 	// ListWorkflowName is the name of the workflow to list SMA points.
 	ListWorkflowName = "ListWorkflow"
@@ -20,24 +33,181 @@ const (
 type (
 	// ListWorkflowParams is the parameters of the List workflow.
 	ListWorkflowParams struct {
-		Exchange     string
-		Pair         string
-		Period       period.Symbol
-		Start        time.Time
-		End          time.Time
-		PeriodNumber int
-		PriceType    candlestick.PriceType
+		Exchange     string                `json:"exchange"`
+		Pair         string                `json:"pair"`
+		Period       period.Symbol         `json:"period"`
+		Start        time.Time             `json:"start"`
+		End          time.Time             `json:"end"`
+		PeriodNumber int                   `json:"period_number"`
+		PriceType    candlestick.PriceType `json:"price_type"`
+		// Format is the shape of the results (points by default).
+		Format ResultFormat `json:"format,omitempty"`
 	}
 
 	// SMADataPoint represents a single SMA data point with its time and value.
 	SMADataPoint struct {
-		Time  time.Time
-		Value float64
+		Time  time.Time `json:"time"`
+		Value float64   `json:"value"`
 	}
 
 	// ListWorkflowResults is the result of the List workflow.
+	// Depending on the requested format, either Data or Compact is set.
 	ListWorkflowResults struct {
-		Data []SMADataPoint
+		Data    []SMADataPoint `json:"data"`
+		Compact *CompactData   `json:"compact,omitempty"`
+	}
+)
+
+// UnmarshalJSON decodes the parameters, also accepting the keys used before
+// the version 1 of the schema.
+func (p *ListWorkflowParams) UnmarshalJSON(data []byte) error {
+	type params ListWorkflowParams
+	return unmarshalWithLegacyKeys(data, (*params)(p))
+}
+
+const (
+	// InvalidateWorkflowName is the name of the workflow to invalidate SMA points.
+	InvalidateWorkflowName = "InvalidateWorkflow"
+)
+
+type (
+	// InvalidateWorkflowParams is the parameters of the Invalidate workflow.
+	// Start and End delimit the candlesticks that have been corrected: every
+	// SMA point computed from at least one of them will be invalidated.
+	InvalidateWorkflowParams struct {
+		Exchange string        `json:"exchange"`
+		Pair     string        `json:"pair"`
+		Period   period.Symbol `json:"period"`
+		Start    time.Time     `json:"start"`
+		End      time.Time     `json:"end"`
+	}
+
+	// InvalidateWorkflowResults is the result of the Invalidate workflow.
+	InvalidateWorkflowResults struct {
+		InvalidatedCount int `json:"invalidated_count"`
+	}
+)
+
+// UnmarshalJSON decodes the results, also accepting the keys used before the
+// version 1 of the schema.
+func (r *InvalidateWorkflowResults) UnmarshalJSON(data []byte) error {
+	type results InvalidateWorkflowResults
+	return unmarshalWithLegacyKeys(data, (*results)(r))
+}
+
+const (
+	// ListSeriesWorkflowName is the name of the workflow to list cached SMA series.
+	ListSeriesWorkflowName = "ListSeriesWorkflow"
+)
+
+type (
+	// ListSeriesWorkflowParams is the parameters of the ListSeries workflow.
+	ListSeriesWorkflowParams struct{}
+
+	// SeriesInfo describes a cached SMA series.
+	SeriesInfo struct {
+		Exchange     string                `json:"exchange"`
+		Pair         string                `json:"pair"`
+		Period       period.Symbol         `json:"period"`
+		PeriodNumber int                   `json:"period_number"`
+		PriceType    candlestick.PriceType `json:"price_type"`
+		First        time.Time             `json:"first"`
+		Last         time.Time             `json:"last"`
+		Count        int                   `json:"count"`
+		GapCount     int                   `json:"gap_count"`
+	}
+
+	// ListSeriesWorkflowResults is the result of the ListSeries workflow.
+	ListSeriesWorkflowResults struct {
+		Series []SeriesInfo `json:"series"`
+	}
+)
+
+// UnmarshalJSON decodes the series info, also accepting the keys used before
+// the version 1 of the schema.
+func (i *SeriesInfo) UnmarshalJSON(data []byte) error {
+	type info SeriesInfo
+	return unmarshalWithLegacyKeys(data, (*info)(i))
+}
+
+const (
+	// ExportWorkflowName is the name of the workflow to export SMA series to a file.
+	ExportWorkflowName = "ExportWorkflow"
+)
+
+type (
+	// ExportWorkflowParams is the parameters of the Export workflow.
+	// Empty filters select every series and zero times do not bound the range.
+	ExportWorkflowParams struct {
+		Exchange     string                `json:"exchange,omitempty"`
+		Pair         string                `json:"pair,omitempty"`
+		Period       period.Symbol         `json:"period,omitempty"`
+		PeriodNumber int                   `json:"period_number,omitempty"`
+		PriceType    candlestick.PriceType `json:"price_type,omitempty"`
+		Start        time.Time             `json:"start"`
+		End          time.Time             `json:"end"`
+		Format       ExportFormat          `json:"format"`
+		// FileName is the name of the created file, relative to the export
+		// directory of the worker.
+		FileName string `json:"file_name"`
+		// Overwrite replaces the file if it already exists. Otherwise, the
+		// export fails with the already exists error type.
+		Overwrite bool `json:"overwrite,omitempty"`
+	}
+
+	// ExportWorkflowResults is the result of the Export workflow.
+	ExportWorkflowResults struct {
+		// Path is the path of the created file, relative to the export
+		// directory of the worker (see EXPORT_DIRECTORY), that is expected to
+		// be shared with the consumers of the exports.
+		Path  string `json:"path"`
+		Count int    `json:"count"`
+	}
+)
+
+const (
+	// VerifyWorkflowName is the name of the workflow to verify the cached SMA
+	// points against a fresh computation.
+	VerifyWorkflowName = "VerifyWorkflow"
+)
+
+type (
+	// VerifyWorkflowParams is the parameters of the Verify workflow.
+	VerifyWorkflowParams struct {
+		Exchange     string                `json:"exchange"`
+		Pair         string                `json:"pair"`
+		Period       period.Symbol         `json:"period"`
+		PeriodNumber int                   `json:"period_number"`
+		PriceType    candlestick.PriceType `json:"price_type"`
+		Start        time.Time             `json:"start"`
+		End          time.Time             `json:"end"`
+		// Tolerance is the maximum absolute difference between a cached and a
+		// recomputed point before it is reported.
+		Tolerance float64 `json:"tolerance"`
+		// SampleSize is the count of cached points, evenly spread on the
+		// range, that are verified. All points are verified if it is 0.
+		SampleSize int `json:"sample_size,omitempty"`
+		// Repair replaces the mismatching cached points by the recomputed ones.
+		Repair bool `json:"repair,omitempty"`
+	}
+
+	// VerifyMismatch is a cached point that differs from the recomputed one.
+	VerifyMismatch struct {
+		Time     time.Time `json:"time"`
+		Cached   float64   `json:"cached"`
+		Computed float64   `json:"computed"`
+	}
+
+	// VerifyWorkflowResults is the result of the Verify workflow.
+	VerifyWorkflowResults struct {
+		// Checked is the count of cached points that have been recomputed and
+		// compared.
+		Checked    int              `json:"checked"`
+		Mismatches []VerifyMismatch `json:"mismatches"`
+		Repaired   int              `json:"repaired"`
+		// Unverifiable is the times of the cached points that could not be
+		// recomputed (e.g. missing candlesticks). They are not in Checked.
+		Unverifiable []time.Time `json:"unverifiable,omitempty"`
 	}
 )
 
@@ -52,6 +222,6 @@ type (
 
 	// ServiceInfoResults contains the result of the service info workflow.
 	ServiceInfoResults struct {
-		Version string
+		Version string `json:"version"`
 	}
 )
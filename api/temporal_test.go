@@ -0,0 +1,299 @@
+//go:build unit
+// +build unit
+
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestWireSchemaSuite(t *testing.T) {
+	suite.Run(t, new(WireSchemaSuite))
+}
+
+type WireSchemaSuite struct {
+	suite.Suite
+}
+
+var (
+	wireStart = time.Date(2023, 2, 26, 12, 0, 0, 0, time.UTC)
+	wireEnd   = time.Date(2023, 2, 26, 12, 2, 0, 0, time.UTC)
+)
+
+// wireCases are the pinned encoded forms of the payloads.
+// They MUST NOT be changed without incrementing SchemaVersion.
+var wireCases = []struct {
+	Name    string
+	Value   any
+	Encoded string
+}{
+	{
+		Name: "ListWorkflowParams",
+		Value: &ListWorkflowParams{
+			Exchange:     "binance",
+			Pair:         "ETH-USDT",
+			Period:       period.M1,
+			Start:        wireStart,
+			End:          wireEnd,
+			PeriodNumber: 3,
+			PriceType:    candlestick.PriceTypeIsClose,
+		},
+		Encoded: `{"exchange":"binance","pair":"ETH-USDT","period":"M1",` +
+			`"start":"2023-02-26T12:00:00Z","end":"2023-02-26T12:02:00Z",` +
+			`"period_number":3,"price_type":"close"}`,
+	},
+	{
+		Name: "ListWorkflowParams",
+		Value: &ListWorkflowParams{
+			Exchange:     "binance",
+			Pair:         "ETH-USDT",
+			Period:       period.M1,
+			Start:        wireStart,
+			End:          wireEnd,
+			PeriodNumber: 3,
+			PriceType:    candlestick.PriceTypeIsClose,
+			Format:       ResultFormatCompact,
+		},
+		Encoded: `{"exchange":"binance","pair":"ETH-USDT","period":"M1",` +
+			`"start":"2023-02-26T12:00:00Z","end":"2023-02-26T12:02:00Z",` +
+			`"period_number":3,"price_type":"close","format":"compact"}`,
+	},
+	{
+		Name: "ListWorkflowResults",
+		Value: &ListWorkflowResults{
+			Data: []SMADataPoint{{Time: wireStart, Value: 1603.5}},
+		},
+		Encoded: `{"data":[{"time":"2023-02-26T12:00:00Z","value":1603.5}]}`,
+	},
+	{
+		Name: "ListWorkflowResults",
+		Value: &ListWorkflowResults{
+			Compact: &CompactData{
+				Start:   wireStart,
+				Period:  period.M1,
+				Values:  []float64{1603.5, 0, 1604},
+				Missing: []byte{0x02},
+			},
+		},
+		Encoded: `{"data":null,"compact":{"start":"2023-02-26T12:00:00Z","period":"M1",` +
+			`"values":[1603.5,0,1604],"missing":"Ag=="}}`,
+	},
+	{
+		Name: "InvalidateWorkflowParams",
+		Value: &InvalidateWorkflowParams{
+			Exchange: "binance",
+			Pair:     "ETH-USDT",
+			Period:   period.M1,
+			Start:    wireStart,
+			End:      wireEnd,
+		},
+		Encoded: `{"exchange":"binance","pair":"ETH-USDT","period":"M1",` +
+			`"start":"2023-02-26T12:00:00Z","end":"2023-02-26T12:02:00Z"}`,
+	},
+	{
+		Name:    "InvalidateWorkflowResults",
+		Value:   &InvalidateWorkflowResults{InvalidatedCount: 12},
+		Encoded: `{"invalidated_count":12}`,
+	},
+	{
+		Name: "ListSeriesWorkflowResults",
+		Value: &ListSeriesWorkflowResults{
+			Series: []SeriesInfo{{
+				Exchange:     "binance",
+				Pair:         "ETH-USDT",
+				Period:       period.M1,
+				PeriodNumber: 3,
+				PriceType:    candlestick.PriceTypeIsClose,
+				First:        wireStart,
+				Last:         wireEnd,
+				Count:        3,
+				GapCount:     0,
+			}},
+		},
+		Encoded: `{"series":[{"exchange":"binance","pair":"ETH-USDT","period":"M1",` +
+			`"period_number":3,"price_type":"close",` +
+			`"first":"2023-02-26T12:00:00Z","last":"2023-02-26T12:02:00Z",` +
+			`"count":3,"gap_count":0}]}`,
+	},
+	{
+		Name: "ExportWorkflowParams",
+		Value: &ExportWorkflowParams{
+			Exchange:  "binance",
+			Start:     wireStart,
+			End:       wireEnd,
+			Format:    ExportFormatParquet,
+			FileName:  "binance.parquet",
+			Overwrite: true,
+		},
+		Encoded: `{"exchange":"binance",` +
+			`"start":"2023-02-26T12:00:00Z","end":"2023-02-26T12:02:00Z",` +
+			`"format":"parquet","file_name":"binance.parquet","overwrite":true}`,
+	},
+	{
+		Name:    "ExportWorkflowResults",
+		Value:   &ExportWorkflowResults{Path: "/exports/binance.parquet", Count: 3},
+		Encoded: `{"path":"/exports/binance.parquet","count":3}`,
+	},
+	{
+		Name: "VerifyWorkflowParams",
+		Value: &VerifyWorkflowParams{
+			Exchange:     "binance",
+			Pair:         "ETH-USDT",
+			Period:       period.M1,
+			PeriodNumber: 3,
+			PriceType:    candlestick.PriceTypeIsClose,
+			Start:        wireStart,
+			End:          wireEnd,
+			Tolerance:    0.01,
+			Repair:       true,
+		},
+		Encoded: `{"exchange":"binance","pair":"ETH-USDT","period":"M1",` +
+			`"period_number":3,"price_type":"close",` +
+			`"start":"2023-02-26T12:00:00Z","end":"2023-02-26T12:02:00Z",` +
+			`"tolerance":0.01,"repair":true}`,
+	},
+	{
+		Name: "VerifyWorkflowResults",
+		Value: &VerifyWorkflowResults{
+			Checked:      3,
+			Mismatches:   []VerifyMismatch{{Time: wireStart, Cached: 1603.5, Computed: 1604}},
+			Repaired:     1,
+			Unverifiable: []time.Time{wireEnd},
+		},
+		Encoded: `{"checked":3,"mismatches":[{"time":"2023-02-26T12:00:00Z",` +
+			`"cached":1603.5,"computed":1604}],"repaired":1,"unverifiable":["2023-02-26T12:02:00Z"]}`,
+	},
+	{
+		Name:    "ServiceInfoResults",
+		Value:   &ServiceInfoResults{Version: "v1.0.0"},
+		Encoded: `{"version":"v1.0.0"}`,
+	},
+}
+
+func (suite *WireSchemaSuite) TestRoundTrip() {
+	for _, c := range wireCases {
+		// Check encoded form
+		b, err := json.Marshal(c.Value)
+		suite.Require().NoError(err, c.Name)
+		suite.Require().JSONEq(c.Encoded, string(b), c.Name)
+
+		// Check decoding gives back the same value
+		decoded := reflect.New(reflect.TypeOf(c.Value).Elem()).Interface()
+		suite.Require().NoError(json.Unmarshal([]byte(c.Encoded), decoded), c.Name)
+		suite.Require().Equal(c.Value, decoded, c.Name)
+	}
+}
+
+func (suite *WireSchemaSuite) TestLegacyKeys() {
+	// Payloads encoded before the version 1 use the Go field names as keys
+	var params ListWorkflowParams
+	suite.Require().NoError(json.Unmarshal([]byte(`{"Exchange":"binance","Pair":"ETH-USDT","Period":"M1",`+
+		`"Start":"2023-02-26T12:00:00Z","End":"2023-02-26T12:02:00Z",`+
+		`"PeriodNumber":3,"PriceType":"close"}`), &params))
+	suite.Require().Equal(ListWorkflowParams{
+		Exchange:     "binance",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		Start:        wireStart,
+		End:          wireEnd,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+	}, params)
+
+	var invalidate InvalidateWorkflowResults
+	suite.Require().NoError(json.Unmarshal([]byte(`{"InvalidatedCount":12}`), &invalidate))
+	suite.Require().Equal(InvalidateWorkflowResults{InvalidatedCount: 12}, invalidate)
+
+	var series ListSeriesWorkflowResults
+	suite.Require().NoError(json.Unmarshal([]byte(`{"Series":[{"Exchange":"binance","PeriodNumber":3,`+
+		`"PriceType":"close","GapCount":2}]}`), &series))
+	suite.Require().Equal(ListSeriesWorkflowResults{Series: []SeriesInfo{{
+		Exchange:     "binance",
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		GapCount:     2,
+	}}}, series)
+
+	// Current keys take precedence over the legacy ones
+	params = ListWorkflowParams{}
+	suite.Require().NoError(json.Unmarshal([]byte(`{"PeriodNumber":3,"period_number":4}`), &params))
+	suite.Require().Equal(4, params.PeriodNumber)
+}
+
+// wireOptionalKeys are the omitempty keys of the payloads, that can be absent
+// from their encoded form. Every other key is required.
+var wireOptionalKeys = map[string][]string{
+	"ListWorkflowParams":    {"format"},
+	"ListWorkflowResults":   {"compact"},
+	"ExportWorkflowParams":  {"exchange", "pair", "period", "period_number", "price_type", "overwrite"},
+	"VerifyWorkflowParams":  {"sample_size", "repair"},
+	"VerifyWorkflowResults": {"unverifiable"},
+}
+
+func (suite *WireSchemaSuite) TestSchemaMatchesPayloads() {
+	var schema struct {
+		Defs map[string]struct {
+			Properties map[string]any `json:"properties"`
+			Required   []string       `json:"required"`
+		} `json:"$defs"`
+	}
+	suite.Require().NoError(json.Unmarshal(SchemaV1, &schema))
+
+	for _, c := range wireCases {
+		def, ok := schema.Defs[c.Name]
+		suite.Require().True(ok, c.Name)
+		optional := wireOptionalKeys[c.Name]
+
+		// Check the optional keys are the omitempty fields
+		var omitempty []string
+		t := reflect.TypeOf(c.Value).Elem()
+		for i := 0; i < t.NumField(); i++ {
+			key, opts, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if opts == "omitempty" {
+				omitempty = append(omitempty, key)
+			}
+		}
+		suite.Require().ElementsMatch(optional, omitempty, c.Name)
+
+		// Get the keys of the encoded payload, with the omitted optional ones
+		var encoded map[string]any
+		suite.Require().NoError(json.Unmarshal([]byte(c.Encoded), &encoded), c.Name)
+		keys := make([]string, 0, len(encoded))
+		for k := range encoded {
+			keys = append(keys, k)
+		}
+		for _, k := range optional {
+			if _, ok := encoded[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		// Check they are the same as the schema ones
+		properties := make([]string, 0, len(def.Properties))
+		for k := range def.Properties {
+			properties = append(properties, k)
+		}
+		sort.Strings(properties)
+		suite.Require().Equal(properties, keys, c.Name)
+
+		// Check the required keys are the non optional ones
+		required := make([]string, 0, len(properties))
+		for _, k := range properties {
+			if !slices.Contains(optional, k) {
+				required = append(required, k)
+			}
+		}
+		suite.Require().ElementsMatch(required, def.Required, c.Name)
+	}
+}
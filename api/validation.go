@@ -0,0 +1,156 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+
+	"github.com/cryptellation/candlesticks/pkg/pair"
+)
+
+// Validate checks if the required fields are filled and valid.
+func (params ListWorkflowParams) Validate() error {
+	if params.Exchange == "" {
+		return errors.New("exchange is required")
+	}
+	if params.Pair == "" {
+		return errors.New("pair is required")
+	} else if err := validatePair(params.Pair); err != nil {
+		return err
+	}
+	if params.Period == "" {
+		return errors.New("period is required")
+	} else if err := params.Period.Validate(); err != nil {
+		return err
+	}
+	if params.PeriodNumber <= 0 {
+		return errors.New("period_number must be greater than 0")
+	}
+	if params.PriceType == "" {
+		return errors.New("price_type is required")
+	} else if err := params.PriceType.Validate(); err != nil {
+		return err
+	}
+	if params.Start.IsZero() {
+		return errors.New("start time is required")
+	}
+	if params.End.IsZero() {
+		return errors.New("end time is required")
+	}
+	if params.End.Before(params.Start) {
+		return errors.New("end time must be after start time")
+	}
+	if err := params.Format.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Normalize validates the parameters and rounds the range on the period, as
+// done before listing the points.
+func (params ListWorkflowParams) Normalize() (ListWorkflowParams, error) {
+	if err := params.Validate(); err != nil {
+		return params, err
+	}
+
+	params.Start = params.Period.RoundTime(params.Start)
+	params.End = params.Period.RoundTime(params.End)
+	return params, nil
+}
+
+// Validate checks if the filled fields are valid.
+func (params ExportWorkflowParams) Validate() error {
+	if params.Pair != "" {
+		if err := validatePair(params.Pair); err != nil {
+			return err
+		}
+	}
+	if params.Period != "" {
+		if err := params.Period.Validate(); err != nil {
+			return err
+		}
+	}
+	if params.PeriodNumber < 0 {
+		return errors.New("period_number must not be negative")
+	}
+	if params.PriceType != "" {
+		if err := params.PriceType.Validate(); err != nil {
+			return err
+		}
+	}
+	if !params.Start.IsZero() && !params.End.IsZero() && params.End.Before(params.Start) {
+		return errors.New("end time must be after start time")
+	}
+	if err := params.Format.Validate(); err != nil {
+		return err
+	}
+	if params.FileName == "" {
+		return errors.New("file_name is required")
+	} else if !filepath.IsLocal(params.FileName) {
+		return fmt.Errorf("file_name %q must be a relative path inside the export directory", params.FileName)
+	}
+	return nil
+}
+
+// Validate checks if the required fields are filled and valid.
+func (params VerifyWorkflowParams) Validate() error {
+	err := ListWorkflowParams{
+		Exchange:     params.Exchange,
+		Pair:         params.Pair,
+		Period:       params.Period,
+		Start:        params.Start,
+		End:          params.End,
+		PeriodNumber: params.PeriodNumber,
+		PriceType:    params.PriceType,
+	}.Validate()
+	if err != nil {
+		return err
+	}
+	if params.Tolerance < 0 || math.IsNaN(params.Tolerance) {
+		return errors.New("tolerance must be a positive number")
+	}
+	if params.SampleSize < 0 {
+		return errors.New("sample_size must not be negative")
+	}
+	return nil
+}
+
+// Validate checks if the required fields are filled and valid.
+func (params InvalidateWorkflowParams) Validate() error {
+	if params.Exchange == "" {
+		return errors.New("exchange is required")
+	}
+	if params.Pair == "" {
+		return errors.New("pair is required")
+	} else if err := validatePair(params.Pair); err != nil {
+		return err
+	}
+	if params.Period == "" {
+		return errors.New("period is required")
+	} else if err := params.Period.Validate(); err != nil {
+		return err
+	}
+	if params.Start.IsZero() {
+		return errors.New("start time is required")
+	}
+	if params.End.IsZero() {
+		return errors.New("end time is required")
+	}
+	if params.End.Before(params.Start) {
+		return errors.New("end time must be after start time")
+	}
+	return nil
+}
+
+// validatePair checks that the pair is formatted as BASE-QUOTE.
+func validatePair(symbol string) error {
+	base, quote, err := pair.ParsePair(symbol)
+	if err != nil {
+		return err
+	}
+	if base == "" || quote == "" {
+		return fmt.Errorf("error parsing pair symbol %q: %w", symbol, pair.ErrInvalidPair)
+	}
+	return nil
+}
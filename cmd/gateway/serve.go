@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/cryptellation/health"
+	"github.com/cryptellation/sma/configs"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/cryptellation/sma/pkg/gateway"
+	"github.com/cryptellation/sma/pkg/grpcserver"
+	"github.com/cryptellation/sma/pkg/logging"
+	"github.com/cryptellation/sma/pkg/metrics"
+	"github.com/cryptellation/sma/pkg/readiness"
+	"github.com/cryptellation/sma/pkg/tracing"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
+
+var serveCmd = &cobra.Command{
+	Use:     "serve",
+	Aliases: []string{"s"},
+	Short:   "Launch the HTTP/JSON gateway",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return serve(cmd.Context(), "sma-gateway", startHTTPGateway)
+	},
+}
+
+var grpcCmd = &cobra.Command{
+	Use:     "grpc",
+	Aliases: []string{"g"},
+	Short:   "Launch the gRPC server",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return serve(cmd.Context(), "sma-grpc", startGRPCServer)
+	},
+}
+
+// serve sets up the health, tracing, metrics and Temporal client shared by
+// the servers, starts the server with start and waits for everything to be
+// finished.
+func serve(
+	ctx context.Context,
+	serviceName string,
+	start func(ctx context.Context, eg *errgroup.Group, cl clients.Client) error,
+) error {
+	// Set up context that cancels on SIGTERM or SIGINT
+	sigCtx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	// Create errgroup and context
+	eg, ctx := errgroup.WithContext(sigCtx)
+
+	// Health server
+	h, err := health.New(viper.GetString(configs.EnvHealthAddress))
+	if err != nil {
+		return err
+	}
+	eg.Go(func() error {
+		return h.Serve(ctx)
+	})
+
+	// Tracing
+	tracingShutdown, err := tracing.Setup(ctx, serviceName, viper.GetString(configs.EnvOTLPEndpoint))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tracingShutdown(context.Background()) }()
+
+	// Metrics server
+	metricsHandler, err := setupAndStartMetricsServer(ctx, eg)
+	if err != nil {
+		return err
+	}
+
+	// Temporal client
+	temporalClient, err := createTemporalClient(ctx, metricsHandler)
+	if err != nil {
+		return err
+	}
+	defer temporalClient.Close()
+
+	// Server
+	cl := clients.New(temporalClient,
+		clients.WithNamespace(viper.GetString(configs.EnvTaskQueueNamespace)))
+	if err := start(ctx, eg, cl); err != nil {
+		return err
+	}
+
+	// Readiness, following the Temporal availability
+	monitor := readiness.New(h.Ready,
+		readiness.WithInterval(viper.GetDuration(configs.EnvReadinessInterval)),
+		readiness.WithTimeout(viper.GetDuration(configs.EnvReadinessTimeout)),
+		readiness.WithFailureThreshold(viper.GetInt(configs.EnvReadinessFailureThreshold)),
+		readiness.WithCheck("temporal",
+			readiness.TemporalCheck(temporalClient, viper.GetString(configs.EnvTemporalNamespace))))
+	eg.Go(func() error {
+		return monitor.Run(ctx)
+	})
+
+	// Wait for everything to be finished
+	err = eg.Wait()
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	return err
+}
+
+// startHTTPGateway starts the HTTP/JSON gateway on the gateway address.
+func startHTTPGateway(ctx context.Context, eg *errgroup.Group, cl clients.Client) error {
+	server := &http.Server{
+		Addr:              viper.GetString(configs.EnvGatewayAddress),
+		Handler:           gateway.New(cl),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	eg.Go(func() error {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	})
+
+	return nil
+}
+
+// startGRPCServer starts the gRPC server on the gRPC address.
+func startGRPCServer(ctx context.Context, eg *errgroup.Group, cl clients.Client) error {
+	lis, err := net.Listen("tcp", viper.GetString(configs.EnvGRPCAddress))
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer()
+	grpcserver.New(cl).Register(grpcServer)
+	eg.Go(func() error {
+		return grpcServer.Serve(lis)
+	})
+	eg.Go(func() error {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+		return nil
+	})
+
+	return nil
+}
+
+// setupAndStartMetricsServer initializes and starts the Prometheus metrics
+// server, returning the handler to use for the Temporal metrics.
+func setupAndStartMetricsServer(ctx context.Context, eg *errgroup.Group) (client.MetricsHandler, error) {
+	// Create registry and handler
+	reg, err := metrics.NewRegistry()
+	if err != nil {
+		return nil, err
+	}
+	h, closer := metrics.NewHandler(reg)
+
+	// Add to errgroup
+	eg.Go(func() error {
+		defer func() { _ = closer.Close() }()
+		return metrics.Serve(ctx, viper.GetString(configs.EnvMetricsAddress), reg)
+	})
+
+	return h, nil
+}
+
+func createTemporalClient(ctx context.Context, metricsHandler client.MetricsHandler) (client.Client, error) {
+	// Create data converter
+	dc, err := clients.NewDataConverter(clients.PayloadEncoding(viper.GetString(configs.EnvPayloadEncoding)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Create tracing interceptor
+	tracingInterceptor, err := tracing.NewInterceptor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Set backoff callback
+	callback := func() (client.Client, error) {
+		return client.Dial(client.Options{
+			HostPort:       viper.GetString(configs.EnvTemporalAddress),
+			Namespace:      viper.GetString(configs.EnvTemporalNamespace),
+			DataConverter:  dc,
+			MetricsHandler: metricsHandler,
+			Logger:         logging.NewTemporalLogger(slog.Default()),
+			Interceptors:   []interceptor.ClientInterceptor{tracingInterceptor},
+		})
+	}
+
+	// Retry with backoff
+	return backoff.Retry(ctx, callback,
+		backoff.WithBackOff(backoff.NewExponentialBackOff()),
+		backoff.WithMaxTries(10))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backfillSeriesFlags   seriesFlags
+	backfillRangeFlags    rangeFlags
+	backfillChunkSizeFlag int
+)
+
+var backfillCmd = &cobra.Command{
+	Use:     "backfill",
+	Aliases: []string{"b"},
+	Short:   "Compute and cache the SMA points of a range, chunk by chunk",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		start, end, err := backfillRangeFlags.times(time.Now())
+		if err != nil {
+			return err
+		}
+
+		params, err := backfillSeriesFlags.listParams(start, end)
+		if err != nil {
+			return err
+		} else if backfillChunkSizeFlag <= 0 {
+			return fmt.Errorf("chunk size must be greater than 0")
+		}
+
+		// Request the range chunk by chunk
+		total := 0
+		err = clients.ListChunks(cmd.Context(), client, params, backfillChunkSizeFlag,
+			func(chunkParams api.ListWorkflowParams, res api.ListWorkflowResults) error {
+				total += len(res.Data)
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "%s - %s: %d points\n",
+					chunkParams.Start.Format(time.RFC3339), chunkParams.End.Format(time.RFC3339), len(res.Data))
+				return err
+			})
+		if err != nil {
+			return fmt.Errorf("backfilling: %w", err)
+		}
+
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Backfilled %d points\n", total)
+		return err
+	},
+}
+
+func addBackfillCommand(cmd *cobra.Command) {
+	backfillSeriesFlags.register(backfillCmd)
+	backfillRangeFlags.register(backfillCmd)
+	backfillCmd.Flags().IntVar(&backfillChunkSizeFlag, "chunk-size", 1000, "Set the count of points requested at once")
+
+	cmd.AddCommand(backfillCmd)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportSeriesFlags   seriesFlags
+	exportRangeFlags    rangeFlags
+	exportFormatFlag    string
+	exportFileFlag      string
+	exportOverwriteFlag bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:     "export",
+	Aliases: []string{"e"},
+	Short:   "Export the cached SMA series to a file on the worker",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		start, end, err := exportRangeFlags.times(time.Now())
+		if err != nil {
+			return err
+		}
+
+		params := api.ExportWorkflowParams{
+			Exchange:     exportSeriesFlags.exchange,
+			Pair:         exportSeriesFlags.pair,
+			Period:       period.Symbol(exportSeriesFlags.period),
+			PeriodNumber: exportSeriesFlags.periodNumber,
+			PriceType:    candlestick.PriceType(exportSeriesFlags.priceType),
+			Start:        start,
+			End:          end,
+			Format:       api.ExportFormat(exportFormatFlag),
+			FileName:     exportFileFlag,
+			Overwrite:    exportOverwriteFlag,
+		}
+		if params.FileName == "" {
+			params.FileName = "sma-" + time.Now().UTC().Format("20060102T150405Z") + params.Format.Extension()
+		}
+		if err := params.Validate(); err != nil {
+			return err
+		}
+
+		res, err := client.Export(cmd.Context(), params)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Exported %d points to %s in the export directory\n",
+			res.Count, res.Path)
+		return err
+	},
+}
+
+func addExportCommand(cmd *cobra.Command) {
+	exportSeriesFlags.registerFilters(exportCmd)
+	exportRangeFlags.registerOptional(exportCmd)
+	exportCmd.Flags().StringVarP(&exportFormatFlag, "format", "f", string(api.ExportFormatCSV),
+		"Set the file format (csv, parquet)")
+	exportCmd.Flags().StringVar(&exportFileFlag, "file", "",
+		"Set the file name, relative to the export directory of the worker (default sma-<time>.<format>)")
+	exportCmd.Flags().BoolVar(&exportOverwriteFlag, "overwrite", false,
+		"Replace the file if it already exists")
+
+	cmd.AddCommand(exportCmd)
+}
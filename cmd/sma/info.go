@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var infoCmd = &cobra.Command{
+	Use:     "info",
+	Aliases: []string{"i"},
+	Short:   "Get the service information",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := client.Info(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Version: %s\n", res.Version)
+		return err
+	},
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var inventoryFormatFlag string
+
+var inventoryCmd = &cobra.Command{
+	Use:     "inventory",
+	Aliases: []string{"inv"},
+	Short:   "List the SMA series cached by the service",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := client.ListSeries(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		return writeSeries(cmd.OutOrStdout(), inventoryFormatFlag, res.Series)
+	},
+}
+
+func addInventoryCommand(cmd *cobra.Command) {
+	inventoryCmd.Flags().StringVarP(&inventoryFormatFlag, "format", "f", "table",
+		"Set the output format (table, json, csv)")
+
+	cmd.AddCommand(inventoryCmd)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	listSeriesFlags seriesFlags
+	listRangeFlags  rangeFlags
+	listFormatFlag  string
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the SMA points of a range",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		start, end, err := listRangeFlags.times(time.Now())
+		if err != nil {
+			return err
+		}
+
+		params, err := listSeriesFlags.listParams(start, end)
+		if err != nil {
+			return err
+		}
+
+		res, err := client.List(cmd.Context(), params)
+		if err != nil {
+			return err
+		}
+
+		return writePoints(cmd.OutOrStdout(), listFormatFlag, res.Data)
+	},
+}
+
+func addListCommand(cmd *cobra.Command) {
+	listSeriesFlags.register(listCmd)
+	listRangeFlags.register(listCmd)
+	listCmd.Flags().StringVarP(&listFormatFlag, "format", "f", "table", "Set the output format (table, json, csv)")
+
+	cmd.AddCommand(listCmd)
+}
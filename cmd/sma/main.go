@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+
+	"github.com/cryptellation/sma/configs"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/cryptellation/version"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	temporalclient "go.temporal.io/sdk/client"
+)
+
+var (
+	temporalAddressFlag   string
+	temporalNamespaceFlag string
+	namespaceFlag         string
+	encodingFlag          string
+)
+
+var (
+	temporalClient temporalclient.Client
+	client         clients.Client
+)
+
+// rootCmd is the sma root command.
+var rootCmd = &cobra.Command{
+	Use:     "sma",
+	Version: version.FullVersion(),
+	Short:   "sma - a command line client for the cryptellation sma service",
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		// Create data converter
+		dc, err := clients.NewDataConverter(clients.PayloadEncoding(encodingFlag))
+		if err != nil {
+			return err
+		}
+
+		// Create clients
+		temporalClient, err = temporalclient.Dial(temporalclient.Options{
+			HostPort:      temporalAddressFlag,
+			Namespace:     temporalNamespaceFlag,
+			DataConverter: dc,
+		})
+		if err != nil {
+			return err
+		}
+		client = clients.New(temporalClient, clients.WithNamespace(namespaceFlag))
+
+		return nil
+	},
+	PersistentPostRun: func(_ *cobra.Command, _ []string) {
+		temporalClient.Close()
+	},
+}
+
+func main() {
+	// Set flags
+	rootCmd.PersistentFlags().StringVar(&temporalAddressFlag, "temporal-address",
+		viper.GetString(configs.EnvTemporalAddress), "Set the Temporal address")
+	rootCmd.PersistentFlags().StringVar(&temporalNamespaceFlag, "temporal-namespace",
+		viper.GetString(configs.EnvTemporalNamespace), "Set the Temporal namespace")
+	rootCmd.PersistentFlags().StringVar(&namespaceFlag, "namespace",
+		viper.GetString(configs.EnvTaskQueueNamespace), "Set the deployment namespace of the service")
+	rootCmd.PersistentFlags().StringVar(&encodingFlag, "encoding",
+		viper.GetString(configs.EnvPayloadEncoding), "Set the payloads encoding (json, protobuf)")
+
+	// Set commands
+	rootCmd.AddCommand(infoCmd)
+	addListCommand(rootCmd)
+	addWatchCommand(rootCmd)
+	addBackfillCommand(rootCmd)
+	addInventoryCommand(rootCmd)
+	addExportCommand(rootCmd)
+
+	// Execute command
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
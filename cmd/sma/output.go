@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cryptellation/sma/api"
+)
+
+// writePoints writes the points in the given format (table, json, csv).
+func writePoints(w io.Writer, format string, points []api.SMADataPoint) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(points)
+	case "csv", "table":
+		pw := newPointWriter(w, format)
+		for _, p := range points {
+			if err := pw.Write(p); err != nil {
+				return err
+			}
+		}
+		return pw.Flush()
+	default:
+		return fmt.Errorf("unknown format: %q", format)
+	}
+}
+
+// pointWriter writes points one by one, for streamed outputs. JSON points
+// are written as one object per line.
+type pointWriter struct {
+	format string
+	w      io.Writer
+	table  *tabwriter.Writer
+	csv    *csv.Writer
+	header bool
+}
+
+func newPointWriter(w io.Writer, format string) *pointWriter {
+	return &pointWriter{
+		format: format,
+		w:      w,
+		table:  tabwriter.NewWriter(w, 0, 0, 2, ' ', 0),
+		csv:    csv.NewWriter(w),
+	}
+}
+
+// Write writes a point.
+func (pw *pointWriter) Write(p api.SMADataPoint) error {
+	t := p.Time.UTC().Format(time.RFC3339)
+	v := strconv.FormatFloat(p.Value, 'f', -1, 64)
+
+	switch pw.format {
+	case "json":
+		return json.NewEncoder(pw.w).Encode(p)
+	case "csv":
+		if !pw.header {
+			pw.header = true
+			if err := pw.csv.Write([]string{"time", "value"}); err != nil {
+				return err
+			}
+		}
+		return pw.csv.Write([]string{t, v})
+	case "table":
+		if !pw.header {
+			pw.header = true
+			fmt.Fprintln(pw.table, "TIME\tVALUE")
+		}
+		_, err := fmt.Fprintf(pw.table, "%s\t%s\n", t, v)
+		return err
+	default:
+		return fmt.Errorf("unknown format: %q", pw.format)
+	}
+}
+
+// Flush flushes the written points.
+func (pw *pointWriter) Flush() error {
+	pw.csv.Flush()
+	if err := pw.csv.Error(); err != nil {
+		return err
+	}
+	return pw.table.Flush()
+}
+
+// writeSeries writes the series in the given format (table, json, csv).
+func writeSeries(w io.Writer, format string, series []api.SeriesInfo) error {
+	header := []string{"exchange", "pair", "period", "period_number", "price_type", "first", "last", "count", "gaps"}
+	rows := make([][]string, 0, len(series))
+	for _, s := range series {
+		rows = append(rows, []string{
+			s.Exchange, s.Pair, s.Period.String(), strconv.Itoa(s.PeriodNumber), s.PriceType.String(),
+			s.First.UTC().Format(time.RFC3339), s.Last.UTC().Format(time.RFC3339),
+			strconv.Itoa(s.Count), strconv.Itoa(s.GapCount),
+		})
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(series)
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "EXCHANGE\tPAIR\tPERIOD\tPERIOD_NUMBER\tPRICE_TYPE\tFIRST\tLAST\tCOUNT\tGAPS")
+		for _, r := range rows {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r[0], r[1], r[2], r[3], r[4], r[5], r[6], r[7], r[8])
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown format: %q", format)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/spf13/cobra"
+)
+
+// seriesFlags are the flags identifying a SMA series.
+type seriesFlags struct {
+	exchange     string
+	pair         string
+	period       string
+	periodNumber int
+	priceType    string
+}
+
+// register sets the flags on the command.
+func (f *seriesFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.exchange, "exchange", "e", "", "Set the exchange of the series")
+	cmd.Flags().StringVarP(&f.pair, "pair", "p", "", "Set the pair of the series (e.g. ETH-USDT)")
+	cmd.Flags().StringVar(&f.period, "period", "", "Set the period of the series (e.g. M1)")
+	cmd.Flags().IntVarP(&f.periodNumber, "period-number", "n", 0, "Set the period number of the series")
+	cmd.Flags().StringVar(&f.priceType, "price-type", string(candlestick.PriceTypeIsClose),
+		"Set the price type of the series")
+	for _, name := range []string{"exchange", "pair", "period", "period-number"} {
+		_ = cmd.MarkFlagRequired(name)
+	}
+}
+
+// registerFilters sets the flags on the command as optional filters, that
+// select every series when they are not set.
+func (f *seriesFlags) registerFilters(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.exchange, "exchange", "e", "", "Only select the series of the exchange")
+	cmd.Flags().StringVarP(&f.pair, "pair", "p", "", "Only select the series of the pair (e.g. ETH-USDT)")
+	cmd.Flags().StringVar(&f.period, "period", "", "Only select the series of the period (e.g. M1)")
+	cmd.Flags().IntVarP(&f.periodNumber, "period-number", "n", 0, "Only select the series of the period number")
+	cmd.Flags().StringVar(&f.priceType, "price-type", "", "Only select the series of the price type")
+}
+
+// listParams returns the list parameters of the series on the given range.
+func (f seriesFlags) listParams(start, end time.Time) (api.ListWorkflowParams, error) {
+	params := api.ListWorkflowParams{
+		Exchange:     f.exchange,
+		Pair:         f.pair,
+		Period:       period.Symbol(f.period),
+		Start:        start,
+		End:          end,
+		PeriodNumber: f.periodNumber,
+		PriceType:    candlestick.PriceType(f.priceType),
+	}
+
+	return params, params.Validate()
+}
+
+// rangeFlags are the flags delimiting a time range.
+type rangeFlags struct {
+	start string
+	end   string
+}
+
+// register sets the flags on the command.
+func (f *rangeFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.start, "start", "",
+		"Set the start of the range (RFC3339, \"now\" or relative like -7d)")
+	cmd.Flags().StringVar(&f.end, "end", "now",
+		"Set the end of the range (RFC3339, \"now\" or relative like -1h)")
+	_ = cmd.MarkFlagRequired("start")
+}
+
+// registerOptional sets the flags on the command, with an unbounded range
+// when they are not set.
+func (f *rangeFlags) registerOptional(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.start, "start", "",
+		"Set the start of the range (RFC3339, \"now\" or relative like -7d)")
+	cmd.Flags().StringVar(&f.end, "end", "",
+		"Set the end of the range (RFC3339, \"now\" or relative like -1h)")
+}
+
+// times returns the start and end of the range. An unset bound is returned
+// as the zero time.
+func (f rangeFlags) times(now time.Time) (start, end time.Time, err error) {
+	if f.start != "" {
+		start, err = parseTime(f.start, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		start = start.UTC()
+	}
+
+	if f.end != "" {
+		end, err = parseTime(f.end, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = end.UTC()
+	}
+
+	return start, end, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var relativeDaysRegex = regexp.MustCompile(`^([+-]?)(\d+)([dw])$`)
+
+// parseTime parses a time given either as RFC3339, as "now" or relatively to
+// now (e.g. "-7d", "-2w", "-1h30m").
+func parseTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "now" {
+		return now, nil
+	}
+
+	// Absolute time
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	// Relative time in days or weeks
+	if m := relativeDaysRegex.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, err
+		}
+		if m[3] == "w" {
+			n *= 7
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		return now.AddDate(0, 0, n), nil
+	}
+
+	// Relative time as a duration
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: expected RFC3339, \"now\" or relative time (e.g. -7d)", s)
+	}
+	return now.Add(d), nil
+}
@@ -0,0 +1,47 @@
+//go:build unit
+// +build unit
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTimesSuite(t *testing.T) {
+	suite.Run(t, new(TimesSuite))
+}
+
+type TimesSuite struct {
+	suite.Suite
+}
+
+func (suite *TimesSuite) TestParseTime() {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		Input    string
+		Expected time.Time
+		Err      bool
+	}{
+		{Input: "now", Expected: now},
+		{Input: "2024-01-01T00:00:00Z", Expected: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Input: "-7d", Expected: time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{Input: "-2w", Expected: time.Date(2024, 2, 25, 12, 0, 0, 0, time.UTC)},
+		{Input: "1d", Expected: time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)},
+		{Input: "-1h30m", Expected: time.Date(2024, 3, 10, 10, 30, 0, 0, time.UTC)},
+		{Input: "yesterday", Err: true},
+	}
+
+	for _, c := range cases {
+		t, err := parseTime(c.Input, now)
+		if c.Err {
+			suite.Require().Error(err, c.Input)
+			continue
+		}
+		suite.Require().NoError(err, c.Input)
+		suite.Require().True(c.Expected.Equal(t), c.Input)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"time"
+
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchSeriesFlags seriesFlags
+	watchDelayFlag   time.Duration
+	watchFormatFlag  string
+)
+
+var watchCmd = &cobra.Command{
+	Use:     "watch",
+	Aliases: []string{"w"},
+	Short:   "Print the SMA point of each period once it is closed",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		params, err := watchSeriesFlags.listParams(time.Now(), time.Now())
+		if err != nil {
+			return err
+		}
+
+		pw := newPointWriter(cmd.OutOrStdout(), watchFormatFlag)
+		return clients.Watch(cmd.Context(), client, params, func(p api.SMADataPoint) error {
+			if err := pw.Write(p); err != nil {
+				return err
+			}
+			return pw.Flush()
+		}, clients.WithWatchDelay(watchDelayFlag))
+	},
+}
+
+func addWatchCommand(cmd *cobra.Command) {
+	watchSeriesFlags.register(watchCmd)
+	watchCmd.Flags().DurationVar(&watchDelayFlag, "delay", 5*time.Second,
+		"Set the delay after the end of a period before getting its point")
+	watchCmd.Flags().StringVarP(&watchFormatFlag, "format", "f", "table", "Set the output format (table, json, csv)")
+
+	cmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/cryptellation/sma/pkg/sma"
+	"github.com/spf13/cobra"
+)
+
+var (
+	computeInputFlag        string
+	computeOutputFlag       string
+	computePeriodFlag       string
+	computePeriodNumberFlag int
+	computePriceTypeFlag    string
+	computeStartFlag        string
+	computeEndFlag          string
+	computeFormatFlag       string
+)
+
+var computeCmd = &cobra.Command{
+	Use:     "compute",
+	Aliases: []string{"c"},
+	Short:   "Compute the SMA points from a candlesticks file, without Temporal nor database",
+	RunE: func(cmd *cobra.Command, _ []string) (err error) {
+		params, err := computeParamsFromFlags()
+		if err != nil {
+			return err
+		}
+
+		// Compute the SMA points
+		ts, err := sma.TimeSerie(params)
+		if err != nil {
+			return err
+		}
+		data := make([]api.SMADataPoint, 0, ts.Len())
+		err = ts.Loop(func(t time.Time, v float64) (bool, error) {
+			data = append(data, api.SMADataPoint{Time: t, Value: v})
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Write them to the output
+		out := cmd.OutOrStdout()
+		if computeOutputFlag != "" {
+			f, err := os.Create(computeOutputFlag)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if closeErr := f.Close(); err == nil {
+					err = closeErr
+				}
+			}()
+			out = f
+		}
+
+		return writeComputedPoints(out, computeFormatFlag, data)
+	},
+}
+
+func computeParamsFromFlags() (sma.TimeSerieParams, error) {
+	per, err := period.FromString(computePeriodFlag)
+	if err != nil {
+		return sma.TimeSerieParams{}, err
+	}
+
+	priceType := candlestick.PriceType(computePriceTypeFlag)
+	if err := priceType.Validate(); err != nil {
+		return sma.TimeSerieParams{}, err
+	}
+
+	if computePeriodNumberFlag <= 0 {
+		return sma.TimeSerieParams{}, errors.New("period number must be greater than 0")
+	}
+
+	list, err := clients.ReadCandlesticksFile(computeInputFlag, "", "", per)
+	if err != nil {
+		return sma.TimeSerieParams{}, err
+	}
+	first, ok := list.First()
+	if !ok {
+		return sma.TimeSerieParams{}, fmt.Errorf("no candlesticks in %q", computeInputFlag)
+	}
+	last, _ := list.Last()
+
+	// Default to the whole range where the SMA can be computed
+	start := first.Time.Add(per.Duration() * time.Duration(computePeriodNumberFlag-1))
+	if computeStartFlag != "" {
+		if start, err = time.Parse(time.RFC3339, computeStartFlag); err != nil {
+			return sma.TimeSerieParams{}, fmt.Errorf("parsing start time: %w", err)
+		}
+	}
+	end := last.Time
+	if computeEndFlag != "" {
+		if end, err = time.Parse(time.RFC3339, computeEndFlag); err != nil {
+			return sma.TimeSerieParams{}, fmt.Errorf("parsing end time: %w", err)
+		}
+	}
+
+	return sma.TimeSerieParams{
+		Candlesticks: list,
+		PriceType:    priceType,
+		Start:        per.RoundTime(start).UTC(),
+		End:          per.RoundTime(end).UTC(),
+		PeriodNumber: computePeriodNumberFlag,
+	}, nil
+}
+
+func writeComputedPoints(w io.Writer, format string, data []api.SMADataPoint) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	case "csv":
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{"time", "value"})
+		for _, d := range data {
+			_ = cw.Write([]string{
+				d.Time.UTC().Format(time.RFC3339),
+				strconv.FormatFloat(d.Value, 'f', -1, 64),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unknown format: %q", format)
+	}
+}
+
+func addComputeCommand(cmd *cobra.Command) {
+	computeCmd.Flags().StringVarP(&computeInputFlag, "input", "i", "",
+		"Set the candlesticks file (CSV with a time,open,high,low,close,volume header, or JSON array)")
+	computeCmd.Flags().StringVarP(&computeOutputFlag, "output", "o", "", "Set the output file (default stdout)")
+	computeCmd.Flags().StringVar(&computePeriodFlag, "period", "", "Set the period of the candlesticks")
+	computeCmd.Flags().IntVarP(&computePeriodNumberFlag, "period-number", "n", 0, "Set the period number of the SMA")
+	computeCmd.Flags().StringVar(&computePriceTypeFlag, "price-type", string(candlestick.PriceTypeIsClose),
+		"Set the price type of the SMA")
+	computeCmd.Flags().StringVar(&computeStartFlag, "start", "",
+		"Set the start of the range (RFC3339, default first computable point)")
+	computeCmd.Flags().StringVar(&computeEndFlag, "end", "",
+		"Set the end of the range (RFC3339, default last candlestick)")
+	computeCmd.Flags().StringVarP(&computeFormatFlag, "format", "f", "csv", "Set the output format (csv, json)")
+	for _, f := range []string{"input", "period", "period-number"} {
+		_ = computeCmd.MarkFlagRequired(f)
+	}
+
+	cmd.AddCommand(computeCmd)
+}
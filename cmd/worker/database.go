@@ -2,13 +2,24 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strconv"
+	"text/tabwriter"
+	"time"
 
 	"github.com/cenkalti/backoff/v5"
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
 	"github.com/cryptellation/dbmigrator"
+	"github.com/cryptellation/sma/api"
 	"github.com/cryptellation/sma/configs"
 	"github.com/cryptellation/sma/configs/sql/down"
 	"github.com/cryptellation/sma/configs/sql/up"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/cryptellation/sma/pkg/sma"
+	smadb "github.com/cryptellation/sma/svc/db"
+	"github.com/cryptellation/sma/svc/db/sql"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/spf13/cobra"
@@ -94,14 +105,313 @@ var rollbackCmd = &cobra.Command{
 	},
 }
 
+var versionsCmd = &cobra.Command{
+	Use:     "versions",
+	Aliases: []string{"v"},
+	Short:   "List the count of SMA points per algorithm version",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		counts, err := sql.NewFromDB(db).CountPointsPerAlgorithmVersion(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		// Print the counts, flagging the outdated ones
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tCOUNT\tSTATUS")
+		for _, c := range counts {
+			status := "current"
+			if c.AlgorithmVersion < sma.AlgorithmVersion {
+				status = "outdated"
+			}
+			fmt.Fprintf(w, "%d\t%d\t%s\n", c.AlgorithmVersion, c.Count, status)
+		}
+		return w.Flush()
+	},
+}
+
+var inventoryFormatFlag string
+
+var inventoryCmd = &cobra.Command{
+	Use:     "inventory",
+	Aliases: []string{"inv"},
+	Short:   "List the SMA series stored in the database",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := sql.NewFromDB(db).ListSeriesActivity(cmd.Context(), smadb.ListSeriesActivityParams{})
+		if err != nil {
+			return err
+		}
+
+		switch inventoryFormatFlag {
+		case "json":
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(res.Series)
+		case "table":
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "EXCHANGE\tPAIR\tPERIOD\tPERIOD_NUMBER\tPRICE_TYPE\tFIRST\tLAST\tCOUNT\tGAPS")
+			for _, s := range res.Series {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%d\t%d\n",
+					s.Exchange, s.Pair, s.Period, s.PeriodNumber, s.PriceType,
+					s.First.Format(time.RFC3339), s.Last.Format(time.RFC3339),
+					s.Count, s.GapCount)
+			}
+			return w.Flush()
+		default:
+			return fmt.Errorf("unknown format: %q", inventoryFormatFlag)
+		}
+	},
+}
+
+var (
+	gapsExchangeFlag     string
+	gapsPairFlag         string
+	gapsPeriodFlag       string
+	gapsPeriodNumberFlag int
+	gapsPriceTypeFlag    string
+	gapsStartFlag        string
+	gapsEndFlag          string
+	gapsFormatFlag       string
+)
+
+var gapsCmd = &cobra.Command{
+	Use:     "gaps",
+	Aliases: []string{"g"},
+	Short:   "Report the missing and invalid points of a SMA series stored in the database",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		params, err := gapReportParamsFromFlags()
+		if err != nil {
+			return err
+		}
+
+		res, err := sql.NewFromDB(db).GapReportActivity(cmd.Context(), params)
+		if err != nil {
+			return err
+		}
+
+		switch gapsFormatFlag {
+		case "json":
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(res)
+		case "table":
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KIND\tSTART\tEND")
+			for _, tr := range res.Missing {
+				fmt.Fprintf(w, "missing\t%s\t%s\n", tr.Start.Format(time.RFC3339), tr.End.Format(time.RFC3339))
+			}
+			for _, tr := range res.Invalid {
+				fmt.Fprintf(w, "invalid\t%s\t%s\n", tr.Start.Format(time.RFC3339), tr.End.Format(time.RFC3339))
+			}
+			return w.Flush()
+		default:
+			return fmt.Errorf("unknown format: %q", gapsFormatFlag)
+		}
+	},
+}
+
+func gapReportParamsFromFlags() (smadb.GapReportActivityParams, error) {
+	per, err := period.FromString(gapsPeriodFlag)
+	if err != nil {
+		return smadb.GapReportActivityParams{}, err
+	}
+
+	priceType := candlestick.PriceType(gapsPriceTypeFlag)
+	if err := priceType.Validate(); err != nil {
+		return smadb.GapReportActivityParams{}, err
+	}
+
+	start, err := time.Parse(time.RFC3339, gapsStartFlag)
+	if err != nil {
+		return smadb.GapReportActivityParams{}, fmt.Errorf("parsing start time: %w", err)
+	}
+
+	end, err := time.Parse(time.RFC3339, gapsEndFlag)
+	if err != nil {
+		return smadb.GapReportActivityParams{}, fmt.Errorf("parsing end time: %w", err)
+	}
+
+	return smadb.GapReportActivityParams{
+		Exchange:     gapsExchangeFlag,
+		Pair:         gapsPairFlag,
+		Period:       per,
+		PeriodNumber: gapsPeriodNumberFlag,
+		PriceType:    priceType,
+		Start:        per.RoundTime(start).UTC(),
+		End:          per.RoundTime(end).UTC(),
+	}, nil
+}
+
+var (
+	verifyExchangeFlag     string
+	verifyPairFlag         string
+	verifyPeriodFlag       string
+	verifyPeriodNumberFlag int
+	verifyPriceTypeFlag    string
+	verifyStartFlag        string
+	verifyEndFlag          string
+	verifyToleranceFlag    float64
+	verifySampleFlag       int
+	verifyRepairFlag       bool
+	verifyFormatFlag       string
+)
+
+// verifiedSeries is the verification report of a stored SMA series.
+type verifiedSeries struct {
+	smadb.SeriesInfo
+	Results api.VerifyWorkflowResults
+}
+
+var verifyCmd = &cobra.Command{
+	Use:     "verify",
+	Aliases: []string{"check"},
+	Short:   "Compare the stored SMA series with a fresh computation from the candlesticks",
+	Long: "Compare the stored SMA series with a fresh computation from the candlesticks.\n" +
+		"The verification of each series is run by the VerifyWorkflow of the workers.",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		series, err := verifySeriesFromFlags(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		// Create temporal client
+		temporalClient, err := createTemporalClient(cmd.Context(), nil)
+		if err != nil {
+			return err
+		}
+		defer temporalClient.Close()
+		client := clients.New(temporalClient,
+			clients.WithNamespace(viper.GetString(configs.EnvTaskQueueNamespace)))
+
+		// Verify each series
+		reports := make([]verifiedSeries, 0, len(series))
+		for _, s := range series {
+			params, err := verifyParamsFromFlags(s)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.Verify(cmd.Context(), params)
+			if err != nil {
+				return fmt.Errorf("verifying %s %s %s %d %s: %w",
+					s.Exchange, s.Pair, s.Period, s.PeriodNumber, s.PriceType, err)
+			}
+			reports = append(reports, verifiedSeries{SeriesInfo: s, Results: res})
+		}
+
+		switch verifyFormatFlag {
+		case "json":
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(reports)
+		case "table":
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "EXCHANGE\tPAIR\tPERIOD\tPERIOD_NUMBER\tPRICE_TYPE\tCHECKED\tMISMATCHES\tREPAIRED\tUNVERIFIABLE")
+			for _, r := range reports {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%d\t%d\t%d\t%d\n",
+					r.Exchange, r.Pair, r.Period, r.PeriodNumber, r.PriceType,
+					r.Results.Checked, len(r.Results.Mismatches), r.Results.Repaired, len(r.Results.Unverifiable))
+			}
+			return w.Flush()
+		default:
+			return fmt.Errorf("unknown format: %q", verifyFormatFlag)
+		}
+	},
+}
+
+// verifySeriesFromFlags returns the stored series selected by the flags.
+func verifySeriesFromFlags(ctx context.Context) ([]smadb.SeriesInfo, error) {
+	res, err := sql.NewFromDB(db).ListSeriesActivity(ctx, smadb.ListSeriesActivityParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]smadb.SeriesInfo, 0, len(res.Series))
+	for _, s := range res.Series {
+		if (verifyExchangeFlag == "" || verifyExchangeFlag == s.Exchange) &&
+			(verifyPairFlag == "" || verifyPairFlag == s.Pair) &&
+			(verifyPeriodFlag == "" || verifyPeriodFlag == s.Period.String()) &&
+			(verifyPeriodNumberFlag == 0 || verifyPeriodNumberFlag == s.PeriodNumber) &&
+			(verifyPriceTypeFlag == "" || verifyPriceTypeFlag == s.PriceType.String()) {
+			series = append(series, s)
+		}
+	}
+
+	return series, nil
+}
+
+// verifyParamsFromFlags returns the verification parameters of the series,
+// on the range of the flags or on the whole series.
+func verifyParamsFromFlags(s smadb.SeriesInfo) (api.VerifyWorkflowParams, error) {
+	params := api.VerifyWorkflowParams{
+		Exchange:     s.Exchange,
+		Pair:         s.Pair,
+		Period:       s.Period,
+		PeriodNumber: s.PeriodNumber,
+		PriceType:    s.PriceType,
+		Start:        s.First,
+		End:          s.Last,
+		Tolerance:    verifyToleranceFlag,
+		SampleSize:   verifySampleFlag,
+		Repair:       verifyRepairFlag,
+	}
+
+	var err error
+	if verifyStartFlag != "" {
+		if params.Start, err = time.Parse(time.RFC3339, verifyStartFlag); err != nil {
+			return api.VerifyWorkflowParams{}, fmt.Errorf("parsing start time: %w", err)
+		}
+	}
+	if verifyEndFlag != "" {
+		if params.End, err = time.Parse(time.RFC3339, verifyEndFlag); err != nil {
+			return api.VerifyWorkflowParams{}, fmt.Errorf("parsing end time: %w", err)
+		}
+	}
+
+	return params, params.Validate()
+}
+
 func addDatabaseCommands(cmd *cobra.Command) {
 	databaseCmd.AddCommand(migrateCmd)
 	databaseCmd.AddCommand(rollbackCmd)
+	databaseCmd.AddCommand(versionsCmd)
+	databaseCmd.AddCommand(inventoryCmd)
+	databaseCmd.AddCommand(gapsCmd)
+	databaseCmd.AddCommand(verifyCmd)
 
 	// Set flags
 	dsn := viper.GetString(configs.EnvSQLDSN)
 	databaseCmd.PersistentFlags().StringVarP(&driverNameFlag, "driver", "d", "postgres", "Set the database driver name")
 	databaseCmd.PersistentFlags().StringVarP(&dsnFlag, "dsn", "s", dsn, "Set the database data source name")
+	inventoryCmd.Flags().StringVarP(&inventoryFormatFlag, "format", "f", "table", "Set the output format (table, json)")
+	gapsCmd.Flags().StringVarP(&gapsExchangeFlag, "exchange", "e", "", "Set the exchange of the series")
+	gapsCmd.Flags().StringVarP(&gapsPairFlag, "pair", "p", "", "Set the pair of the series")
+	gapsCmd.Flags().StringVar(&gapsPeriodFlag, "period", "", "Set the period of the series")
+	gapsCmd.Flags().IntVarP(&gapsPeriodNumberFlag, "period-number", "n", 0, "Set the period number of the series")
+	gapsCmd.Flags().StringVar(&gapsPriceTypeFlag, "price-type", string(candlestick.PriceTypeIsClose),
+		"Set the price type of the series")
+	gapsCmd.Flags().StringVar(&gapsStartFlag, "start", "", "Set the start of the range (RFC3339)")
+	gapsCmd.Flags().StringVar(&gapsEndFlag, "end", "", "Set the end of the range (RFC3339)")
+	gapsCmd.Flags().StringVarP(&gapsFormatFlag, "format", "f", "table", "Set the output format (table, json)")
+	for _, f := range []string{"exchange", "pair", "period", "period-number", "start", "end"} {
+		_ = gapsCmd.MarkFlagRequired(f)
+	}
+
+	verifyCmd.Flags().StringVarP(&verifyExchangeFlag, "exchange", "e", "", "Only verify the series of the exchange")
+	verifyCmd.Flags().StringVarP(&verifyPairFlag, "pair", "p", "", "Only verify the series of the pair")
+	verifyCmd.Flags().StringVar(&verifyPeriodFlag, "period", "", "Only verify the series of the period")
+	verifyCmd.Flags().IntVarP(&verifyPeriodNumberFlag, "period-number", "n", 0,
+		"Only verify the series of the period number")
+	verifyCmd.Flags().StringVar(&verifyPriceTypeFlag, "price-type", "", "Only verify the series of the price type")
+	verifyCmd.Flags().StringVar(&verifyStartFlag, "start", "",
+		"Set the start of the verified range (RFC3339, default first point of the series)")
+	verifyCmd.Flags().StringVar(&verifyEndFlag, "end", "",
+		"Set the end of the verified range (RFC3339, default last point of the series)")
+	verifyCmd.Flags().Float64VarP(&verifyToleranceFlag, "tolerance", "t", 1e-9,
+		"Set the maximum absolute difference between a stored and a recomputed point")
+	verifyCmd.Flags().IntVar(&verifySampleFlag, "sample", 0,
+		"Set the count of points verified per series, evenly spread (0 for a full scan)")
+	verifyCmd.Flags().BoolVar(&verifyRepairFlag, "repair", false, "Replace the mismatching points by the recomputed ones")
+	verifyCmd.Flags().StringVarP(&verifyFormatFlag, "format", "f", "table", "Set the output format (table, json)")
 
 	cmd.AddCommand(databaseCmd)
 }
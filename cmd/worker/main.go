@@ -1,10 +1,14 @@
 package main
 
 import (
+	"log/slog"
 	"os"
 
+	"github.com/cryptellation/sma/configs"
+	"github.com/cryptellation/sma/pkg/logging"
 	"github.com/cryptellation/version"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // rootCmd is the worker root command.
@@ -12,12 +16,28 @@ var rootCmd = &cobra.Command{
 	Use:     "worker",
 	Version: version.FullVersion(),
 	Short:   "worker - a worker executing cryptellation sma temporal workflows",
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		// Set the default logger, also used by the Temporal client
+		logger, err := logging.New(os.Stderr,
+			viper.GetString(configs.EnvLogLevel),
+			logging.Format(viper.GetString(configs.EnvLogFormat)))
+		if err != nil {
+			return err
+		}
+		slog.SetDefault(logger)
+
+		return nil
+	},
 }
 
 func main() {
+	// Run the root hooks (e.g. logger) before the ones of the subcommands
+	cobra.EnableTraverseRunHooks = true
+
 	// Set commands
 	rootCmd.AddCommand(serveCmd)
 	addDatabaseCommands(rootCmd)
+	addComputeCommand(rootCmd)
 
 	// Execute command
 	if err := rootCmd.Execute(); err != nil {
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"os/signal"
 	"syscall"
 
@@ -10,15 +12,27 @@ import (
 	"github.com/cryptellation/health"
 	"github.com/cryptellation/sma/api"
 	"github.com/cryptellation/sma/configs"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/cryptellation/sma/pkg/logging"
+	"github.com/cryptellation/sma/pkg/metrics"
+	"github.com/cryptellation/sma/pkg/readiness"
+	"github.com/cryptellation/sma/pkg/tracing"
 	"github.com/cryptellation/sma/svc"
+	smadb "github.com/cryptellation/sma/svc/db"
+	"github.com/cryptellation/sma/svc/db/mem"
 	"github.com/cryptellation/sma/svc/db/sql"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
 	temporalwk "go.temporal.io/sdk/worker"
 	"golang.org/x/sync/errgroup"
 )
 
+// errWorkerStopped is returned when the worker loop stops without error nor
+// cancellation.
+var errWorkerStopped = errors.New("temporal worker stopped unexpectedly")
+
 var serveCmd = &cobra.Command{
 	Use:     "serve",
 	Aliases: []string{"s"},
@@ -40,21 +54,34 @@ func serve(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	// Metrics server
+	metricsHandler, err := setupAndStartMetricsServer(ctx, eg)
+	if err != nil {
+		return err
+	}
+
+	// Tracing
+	tracingShutdown, err := tracing.Setup(ctx, "sma-worker", viper.GetString(configs.EnvOTLPEndpoint))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tracingShutdown(context.Background()) }()
+
 	// Temporal worker
-	w, workerCleanup, err := setupWorker(ctx, eg)
+	temporalClient, w, workerCleanup, err := setupWorker(ctx, eg, metricsHandler)
 	if err != nil {
 		return err
 	}
 	defer workerCleanup()
 
 	// Service
-	if err := setupService(ctx, w); err != nil {
+	db, err := setupService(ctx, w)
+	if err != nil {
 		return err
 	}
 
-	// Signal health server is ready
-	h.Ready(true)
-	defer h.Ready(false)
+	// Readiness, following the dependencies availability
+	startReadinessMonitor(ctx, eg, h, db, temporalClient)
 
 	// Wait for everything to be finished
 	err = eg.Wait()
@@ -83,16 +110,42 @@ func setupAndStartHealthServer(ctx context.Context, eg *errgroup.Group) (*health
 	return h, nil
 }
 
-// setupWorker creates the temporal client and worker, and returns the worker and a cleanup function.
-func setupWorker(ctx context.Context, eg *errgroup.Group) (temporalwk.Worker, func(), error) {
+// setupAndStartMetricsServer initializes and starts the Prometheus metrics
+// server, returning the handler to use for the Temporal metrics.
+func setupAndStartMetricsServer(ctx context.Context, eg *errgroup.Group) (client.MetricsHandler, error) {
+	// Create registry and handler
+	reg, err := metrics.NewRegistry()
+	if err != nil {
+		return nil, err
+	}
+	h, closer := metrics.NewHandler(reg)
+
+	// Add to errgroup
+	eg.Go(func() error {
+		defer func() { _ = closer.Close() }()
+		return metrics.Serve(ctx, viper.GetString(configs.EnvMetricsAddress), reg)
+	})
+
+	return h, nil
+}
+
+// setupWorker creates the temporal client and worker, and returns them and a cleanup function.
+// The worker loop stopping on its own is an error, so that the whole service stops
+// and its liveness fails instead of staying alive without processing anything.
+func setupWorker(
+	ctx context.Context,
+	eg *errgroup.Group,
+	metricsHandler client.MetricsHandler,
+) (client.Client, temporalwk.Worker, func(), error) {
 	// Create temporal client
-	temporalClient, err := createTemporalClient(ctx)
+	temporalClient, err := createTemporalClient(ctx, metricsHandler)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Create temporal worker and add to errgroup
-	w := temporalwk.New(temporalClient, api.WorkerTaskQueueName, temporalwk.Options{})
+	taskQueue := api.TaskQueueName(viper.GetString(configs.EnvTaskQueueNamespace))
+	w := temporalwk.New(temporalClient, taskQueue, temporalwk.Options{})
 	eg.Go(func() error {
 		runErr := make(chan error, 1)
 		go func() {
@@ -105,37 +158,84 @@ func setupWorker(ctx context.Context, eg *errgroup.Group) (temporalwk.Worker, fu
 			<-runErr
 			return ctx.Err()
 		case err := <-runErr:
-			return err
+			if err == nil {
+				err = errWorkerStopped
+			}
+			return fmt.Errorf("running temporal worker: %w", err)
 		}
 	})
 
 	// Cleanup function
 	cleanup := func() { temporalClient.Close() }
 
-	return w, cleanup, nil
+	return temporalClient, w, cleanup, nil
 }
 
-// setupService creates the db and service and registers them to the worker.
-func setupService(ctx context.Context, w temporalwk.Worker) error {
+// setupService creates the db and service, registers them to the worker and
+// returns the db.
+func setupService(ctx context.Context, w temporalwk.Worker) (smadb.DB, error) {
 	// Create db client
 	db, err := createDBClient(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	db.Register(w)
 
 	// Create service
-	service := svc.New(db)
+	service := svc.New(db,
+		svc.WithMaxPeriodNumber(viper.GetInt(configs.EnvMaxPeriodNumber)),
+		svc.WithMaxRangePoints(viper.GetInt(configs.EnvMaxRangePoints)),
+		svc.WithSupportedPairCheck(viper.GetBool(configs.EnvCheckSupportedPair)),
+		svc.WithExportDirectory(viper.GetString(configs.EnvExportDirectory)))
 	service.Register(w)
 
-	return nil
+	return db, nil
+}
+
+// startReadinessMonitor periodically checks the database and Temporal, and
+// sets the readiness of the health server accordingly.
+func startReadinessMonitor(
+	ctx context.Context,
+	eg *errgroup.Group,
+	h *health.Health,
+	db smadb.DB,
+	temporalClient client.Client,
+) {
+	monitor := readiness.New(h.Ready,
+		readiness.WithInterval(viper.GetDuration(configs.EnvReadinessInterval)),
+		readiness.WithTimeout(viper.GetDuration(configs.EnvReadinessTimeout)),
+		readiness.WithFailureThreshold(viper.GetInt(configs.EnvReadinessFailureThreshold)),
+		readiness.WithCheck("database", db.Ping),
+		readiness.WithCheck("temporal",
+			readiness.TemporalCheck(temporalClient, viper.GetString(configs.EnvTemporalNamespace))))
+
+	eg.Go(func() error {
+		return monitor.Run(ctx)
+	})
 }
 
-func createTemporalClient(ctx context.Context) (client.Client, error) {
+func createTemporalClient(ctx context.Context, metricsHandler client.MetricsHandler) (client.Client, error) {
+	// Create data converter
+	dc, err := clients.NewDataConverter(clients.PayloadEncoding(viper.GetString(configs.EnvPayloadEncoding)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Create tracing interceptor
+	tracingInterceptor, err := tracing.NewInterceptor()
+	if err != nil {
+		return nil, err
+	}
+
 	// Set backoff callback
 	callback := func() (client.Client, error) {
 		return client.Dial(client.Options{
-			HostPort: viper.GetString(configs.EnvTemporalAddress),
+			HostPort:       viper.GetString(configs.EnvTemporalAddress),
+			Namespace:      viper.GetString(configs.EnvTemporalNamespace),
+			DataConverter:  dc,
+			MetricsHandler: metricsHandler,
+			Logger:         logging.NewTemporalLogger(slog.Default()),
+			Interceptors:   []interceptor.ClientInterceptor{tracingInterceptor},
 		})
 	}
 
@@ -145,9 +245,17 @@ func createTemporalClient(ctx context.Context) (client.Client, error) {
 		backoff.WithMaxTries(10))
 }
 
-func createDBClient(ctx context.Context) (*sql.Activities, error) {
+func createDBClient(ctx context.Context) (smadb.DB, error) {
+	// Use in-memory database if requested
+	dbType := viper.GetString(configs.EnvDBType)
+	if dbType == "mem" {
+		return mem.New(), nil
+	} else if dbType != "sql" {
+		return nil, fmt.Errorf("unknown database type: %q", dbType)
+	}
+
 	// Set backoff callback with dummy return value
-	callback := func() (*sql.Activities, error) {
+	callback := func() (smadb.DB, error) {
 		return sql.New(ctx, viper.GetString(configs.EnvSQLDSN))
 	}
 
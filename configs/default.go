@@ -1,6 +1,11 @@
 package configs
 
+import "time"
+
 const (
+	// DefaultDBType is the default database type.
+	DefaultDBType = "sql"
+
 	// DefaultDBDSN is the default database DSN.
 	DefaultDBDSN = "host=localhost " +
 		"user=cryptellation " +
@@ -17,6 +22,57 @@ const (
 	// DefaultTemporalAddress is the default Temporal address.
 	DefaultTemporalAddress = "localhost:7233"
 
+	// DefaultTemporalNamespace is the default Temporal namespace.
+	DefaultTemporalNamespace = "default"
+
+	// DefaultTaskQueueNamespace is the default deployment namespace of the worker task queue.
+	DefaultTaskQueueNamespace = ""
+
+	// DefaultPayloadEncoding is the default encoding of the workflows payloads.
+	DefaultPayloadEncoding = "json"
+
+	// DefaultMaxPeriodNumber is the default maximum period number of a request.
+	// There is no limit by default: it is opt-in.
+	DefaultMaxPeriodNumber = 0
+
+	// DefaultMaxRangePoints is the default maximum count of points of a request.
+	// There is no limit by default: it is opt-in.
+	DefaultMaxRangePoints = 0
+
+	// DefaultCheckSupportedPair is the default activation of the supported pair check.
+	DefaultCheckSupportedPair = false
+
+	// DefaultExportDirectory is the default directory of the exported files.
+	DefaultExportDirectory = "exports"
+
+	// DefaultLogLevel is the default minimum level of the log lines.
+	DefaultLogLevel = "info"
+
+	// DefaultLogFormat is the default format of the log lines.
+	DefaultLogFormat = "text"
+
 	// DefaultHealthAddress is the default health address.
 	DefaultHealthAddress = ":9000"
+
+	// DefaultReadinessInterval is the default interval between two readiness checks.
+	DefaultReadinessInterval = 10 * time.Second
+
+	// DefaultReadinessTimeout is the default timeout of a single readiness check.
+	DefaultReadinessTimeout = 5 * time.Second
+
+	// DefaultReadinessFailureThreshold is the default count of consecutive
+	// failures of a dependency check before the service is not ready anymore.
+	DefaultReadinessFailureThreshold = 3
+
+	// DefaultMetricsAddress is the default Prometheus metrics address.
+	DefaultMetricsAddress = ":9100"
+
+	// DefaultOTLPEndpoint is the default OTLP endpoint, disabling the traces export.
+	DefaultOTLPEndpoint = ""
+
+	// DefaultGatewayAddress is the default HTTP gateway address.
+	DefaultGatewayAddress = ":8080"
+
+	// DefaultGRPCAddress is the default gRPC server address.
+	DefaultGRPCAddress = ":9090"
 )
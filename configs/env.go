@@ -2,6 +2,10 @@ package configs
 
 import "github.com/spf13/viper"
 
+// EnvDBType is the environment variable name for the database type in the config.
+// It can be either "sql" (PostgreSQL) or "mem" (in-memory, not persisted).
+const EnvDBType = "DB_TYPE"
+
 // EnvSQLDSN is the environment variable name for the database DSN in the config.
 const EnvSQLDSN = "SQL_DSN"
 
@@ -14,17 +18,99 @@ const EnvBinanceSecretKey = "BINANCE_SECRET_KEY"
 // EnvTemporalAddress is the environment variable name for the Temporal address in the config.
 const EnvTemporalAddress = "TEMPORAL_ADDRESS"
 
+// EnvTemporalNamespace is the environment variable name for the Temporal
+// namespace of the workflows in the config.
+const EnvTemporalNamespace = "TEMPORAL_NAMESPACE"
+
+// EnvTaskQueueNamespace is the environment variable name for the deployment
+// namespace of the worker task queue in the config (e.g. "staging" or "prod").
+const EnvTaskQueueNamespace = "TASK_QUEUE_NAMESPACE"
+
+// EnvPayloadEncoding is the environment variable name for the encoding of the
+// workflows payloads in the config. It can be either "json" or "protobuf".
+const EnvPayloadEncoding = "PAYLOAD_ENCODING"
+
+// EnvMaxPeriodNumber is the environment variable name for the maximum period
+// number of a request in the config (0 for no limit, the default).
+const EnvMaxPeriodNumber = "MAX_PERIOD_NUMBER"
+
+// EnvMaxRangePoints is the environment variable name for the maximum count of
+// points of a request in the config (0 for no limit, the default).
+const EnvMaxRangePoints = "MAX_RANGE_POINTS"
+
+// EnvCheckSupportedPair is the environment variable name for the activation of
+// the supported pair check with the candlesticks service in the config.
+const EnvCheckSupportedPair = "CHECK_SUPPORTED_PAIR"
+
+// EnvExportDirectory is the environment variable name for the directory where
+// the worker creates the exported files in the config.
+const EnvExportDirectory = "EXPORT_DIRECTORY"
+
+// EnvLogLevel is the environment variable name for the minimum level of the
+// log lines in the config. It can be "debug", "info", "warn" or "error".
+const EnvLogLevel = "LOG_LEVEL"
+
+// EnvLogFormat is the environment variable name for the format of the log
+// lines in the config. It can be either "text" or "json".
+const EnvLogFormat = "LOG_FORMAT"
+
 // EnvHealthAddress is the environment variable name for the health address in the config.
 const EnvHealthAddress = "HEALTH_ADDRESS"
 
+// EnvReadinessInterval is the environment variable name for the interval
+// between two readiness checks of the dependencies in the config (e.g. "10s").
+const EnvReadinessInterval = "READINESS_INTERVAL"
+
+// EnvReadinessTimeout is the environment variable name for the timeout of a
+// single readiness check in the config (e.g. "5s").
+const EnvReadinessTimeout = "READINESS_TIMEOUT"
+
+// EnvReadinessFailureThreshold is the environment variable name for the count
+// of consecutive failures of a dependency check before the service is not
+// ready anymore in the config.
+const EnvReadinessFailureThreshold = "READINESS_FAILURE_THRESHOLD"
+
+// EnvMetricsAddress is the environment variable name for the Prometheus metrics address in the config.
+const EnvMetricsAddress = "METRICS_ADDRESS"
+
+// EnvOTLPEndpoint is the environment variable name for the OTLP gRPC endpoint
+// where the traces are exported in the config (e.g. "http://localhost:4317").
+// Traces are not exported if it is empty.
+const EnvOTLPEndpoint = "OTLP_ENDPOINT"
+
+// EnvGatewayAddress is the environment variable name for the HTTP gateway
+// address in the config, used by the "gateway serve" command.
+const EnvGatewayAddress = "GATEWAY_ADDRESS"
+
+// EnvGRPCAddress is the environment variable name for the gRPC server address
+// in the config, used by the "gateway grpc" command.
+const EnvGRPCAddress = "GRPC_ADDRESS"
+
 func init() {
 	// Tell viper to read environment variables
 	viper.AutomaticEnv()
 
 	// Set default values for the config
+	viper.SetDefault(EnvDBType, DefaultDBType)
 	viper.SetDefault(EnvSQLDSN, DefaultDBDSN)
 	viper.SetDefault(EnvBinanceAPIKey, DefaultBinanceAPIKey)
 	viper.SetDefault(EnvBinanceSecretKey, DefaultBinanceSecretKey)
 	viper.SetDefault(EnvTemporalAddress, DefaultTemporalAddress)
+	viper.SetDefault(EnvTemporalNamespace, DefaultTemporalNamespace)
+	viper.SetDefault(EnvTaskQueueNamespace, DefaultTaskQueueNamespace)
+	viper.SetDefault(EnvPayloadEncoding, DefaultPayloadEncoding)
+	viper.SetDefault(EnvMaxPeriodNumber, DefaultMaxPeriodNumber)
+	viper.SetDefault(EnvMaxRangePoints, DefaultMaxRangePoints)
+	viper.SetDefault(EnvCheckSupportedPair, DefaultCheckSupportedPair)
+	viper.SetDefault(EnvExportDirectory, DefaultExportDirectory)
+	viper.SetDefault(EnvLogLevel, DefaultLogLevel)
+	viper.SetDefault(EnvLogFormat, DefaultLogFormat)
 	viper.SetDefault(EnvHealthAddress, DefaultHealthAddress)
+	viper.SetDefault(EnvReadinessInterval, DefaultReadinessInterval)
+	viper.SetDefault(EnvReadinessTimeout, DefaultReadinessTimeout)
+	viper.SetDefault(EnvReadinessFailureThreshold, DefaultReadinessFailureThreshold)
+	viper.SetDefault(EnvMetricsAddress, DefaultMetricsAddress)
+	viper.SetDefault(EnvOTLPEndpoint, DefaultOTLPEndpoint)
+	viper.SetDefault(EnvGatewayAddress, DefaultGatewayAddress)
+	viper.SetDefault(EnvGRPCAddress, DefaultGRPCAddress)
 }
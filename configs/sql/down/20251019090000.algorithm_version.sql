@@ -0,0 +1,3 @@
+ALTER TABLE sma
+    DROP COLUMN algorithm_version,
+    DROP COLUMN computed_at;
\ No newline at end of file
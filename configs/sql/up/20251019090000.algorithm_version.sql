@@ -0,0 +1,3 @@
+ALTER TABLE sma
+    ADD COLUMN algorithm_version INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1,64 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/cryptellation/sma/api"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+// ListHandle identifies a list workflow started with StartList.
+type ListHandle struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+}
+
+// StartList starts the list workflow without waiting for its results.
+func (c client) StartList(
+	ctx context.Context,
+	params api.ListWorkflowParams,
+	opts ...WorkflowOption,
+) (ListHandle, error) {
+	workflowOptions := c.workflowOptions(ListWorkflowID(params), opts...)
+
+	// Start workflow
+	exec, err := c.temporal.ExecuteWorkflow(ctx, workflowOptions, api.ListWorkflowName, params)
+	if err != nil {
+		return ListHandle{}, err
+	}
+
+	return ListHandle{
+		WorkflowID: exec.GetID(),
+		RunID:      exec.GetRunID(),
+	}, nil
+}
+
+// GetListResult waits for the results of a list workflow started with StartList.
+func (c client) GetListResult(
+	ctx context.Context,
+	handle ListHandle,
+) (res api.ListWorkflowResults, err error) {
+	err = c.temporal.GetWorkflow(ctx, handle.WorkflowID, handle.RunID).Get(ctx, &res)
+	return res, err
+}
+
+// PollList returns the results of a list workflow started with StartList
+// if it is over, without waiting for it otherwise.
+func (c client) PollList(
+	ctx context.Context,
+	handle ListHandle,
+) (res api.ListWorkflowResults, done bool, err error) {
+	// Check if the workflow is still running
+	desc, err := c.temporal.DescribeWorkflowExecution(ctx, handle.WorkflowID, handle.RunID)
+	if err != nil {
+		return api.ListWorkflowResults{}, false, err
+	}
+	status := desc.GetWorkflowExecutionInfo().GetStatus()
+	if status == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		return api.ListWorkflowResults{}, false, nil
+	}
+
+	// Get the results (or the error) of the closed workflow
+	res, err = c.GetListResult(ctx, handle)
+	return res, true, err
+}
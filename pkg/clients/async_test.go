@@ -0,0 +1,97 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+)
+
+func TestAsyncSuite(t *testing.T) {
+	suite.Run(t, new(AsyncSuite))
+}
+
+type AsyncSuite struct {
+	suite.Suite
+	temporal *mocks.Client
+	client   Client
+}
+
+func (suite *AsyncSuite) SetupTest() {
+	suite.temporal = mocks.NewClient(suite.T())
+	suite.client = New(suite.temporal)
+}
+
+func (suite *AsyncSuite) describe(status enumspb.WorkflowExecutionStatus) {
+	suite.temporal.On("DescribeWorkflowExecution", mock.Anything, "wf-id", "run-id").
+		Return(&workflowservice.DescribeWorkflowExecutionResponse{
+			WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{Status: status},
+		}, nil).Once()
+}
+
+func (suite *AsyncSuite) TestStartList() {
+	params := api.ListWorkflowParams{Exchange: "binance", Pair: "BTC-USDT"}
+
+	run := mocks.NewWorkflowRun(suite.T())
+	run.On("GetID").Return("wf-id")
+	run.On("GetRunID").Return("run-id")
+	suite.temporal.On("ExecuteWorkflow", mock.Anything, mock.Anything, api.ListWorkflowName, params).
+		Return(run, nil).Once()
+
+	handle, err := suite.client.StartList(context.Background(), params)
+	suite.Require().NoError(err)
+	suite.Require().Equal(ListHandle{WorkflowID: "wf-id", RunID: "run-id"}, handle)
+}
+
+func (suite *AsyncSuite) TestExportInProgress() {
+	params := api.ExportWorkflowParams{Format: api.ExportFormatCSV, FileName: "export.csv"}
+
+	// GIVEN an export of the same file that is running
+	suite.temporal.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(o temporalclient.StartWorkflowOptions) bool {
+		return o.WorkflowIDConflictPolicy == enumspb.WORKFLOW_ID_CONFLICT_POLICY_FAIL &&
+			o.WorkflowExecutionErrorWhenAlreadyStarted
+	}), api.ExportWorkflowName, params).
+		Return(nil, serviceerror.NewWorkflowExecutionAlreadyStarted("started", "", "run-id")).Once()
+
+	// WHEN exporting
+	_, err := suite.client.Export(context.Background(), params)
+
+	// THEN the export is refused instead of attached to the running one
+	suite.Require().ErrorIs(err, ErrExportInProgress)
+}
+
+func (suite *AsyncSuite) TestPollList() {
+	handle := ListHandle{WorkflowID: "wf-id", RunID: "run-id"}
+
+	// Still running
+	suite.describe(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	_, done, err := suite.client.PollList(context.Background(), handle)
+	suite.Require().NoError(err)
+	suite.Require().False(done)
+
+	// Completed
+	suite.describe(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	run := mocks.NewWorkflowRun(suite.T())
+	run.On("Get", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		res := args.Get(1).(*api.ListWorkflowResults)
+		res.Data = []api.SMADataPoint{{Value: 42}}
+	}).Return(nil).Once()
+	suite.temporal.On("GetWorkflow", mock.Anything, "wf-id", "run-id").Return(run).Once()
+
+	res, done, err := suite.client.PollList(context.Background(), handle)
+	suite.Require().NoError(err)
+	suite.Require().True(done)
+	suite.Require().Len(res.Data, 1)
+	suite.Require().Equal(42.0, res.Data[0].Value)
+}
@@ -0,0 +1,365 @@
+package clients
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/timeseries"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultCacheSize is the default maximum count of points kept by the LRU cache.
+const DefaultCacheSize = 1_000_000
+
+// CacheKey identifies a cached SMA series.
+type CacheKey struct {
+	Exchange     string
+	Pair         string
+	Period       period.Symbol
+	PeriodNumber int
+	PriceType    candlestick.PriceType
+}
+
+// CacheBackend stores the cached SMA series. Implementations must be safe for
+// concurrent use and must not modify the series they are given.
+type CacheBackend interface {
+	// Get returns the series corresponding to the key, if any.
+	Get(key CacheKey) (*timeseries.TimeSerie[float64], bool)
+	// Set replaces the series corresponding to the key.
+	Set(key CacheKey, ts *timeseries.TimeSerie[float64])
+	// Delete removes the series corresponding to the keys.
+	Delete(keys ...CacheKey)
+	// Keys returns the keys of the cached series.
+	Keys() []CacheKey
+}
+
+// CacheOption is an option of the cached client.
+type CacheOption func(c *cachedClient)
+
+// WithCacheBackend sets the backend storing the cached series (an in-memory
+// LRU cache of DefaultCacheSize points by default).
+func WithCacheBackend(backend CacheBackend) CacheOption {
+	return func(c *cachedClient) {
+		c.backend = backend
+	}
+}
+
+type cachedClient struct {
+	Client
+	backend CacheBackend
+	mutex   sync.Mutex
+	flights singleflight.Group
+	now     func() time.Time
+}
+
+// NewCached creates a client caching the SMA points returned by the List
+// method of the given client. Only points of closed periods are cached:
+// requests are served from the cache and only the missing points and the
+// still-open tail of the range are requested to the underlying client.
+// Concurrent identical misses share a single request to the underlying client.
+//
+// Other methods are passed through, except Invalidate that also removes the
+// invalidated series from the cache.
+func NewCached(next Client, opts ...CacheOption) Client {
+	c := &cachedClient{
+		Client: next,
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.backend == nil {
+		c.backend = NewLRUCache(DefaultCacheSize)
+	}
+
+	return c
+}
+
+// List returns the SMA points, from the cache when possible.
+func (c *cachedClient) List(
+	ctx context.Context,
+	params api.ListWorkflowParams,
+	opts ...WorkflowOption,
+) (api.ListWorkflowResults, error) {
+	// Let the underlying client reject invalid requests
+	normalized, err := params.Normalize()
+	if err != nil {
+		return c.Client.List(ctx, params, opts...)
+	}
+	params = normalized
+	key := CacheKey{
+		Exchange:     params.Exchange,
+		Pair:         params.Pair,
+		Period:       params.Period,
+		PeriodNumber: params.PeriodNumber,
+		PriceType:    params.PriceType,
+	}
+
+	// Get the cached points
+	cached, ok := c.backend.Get(key)
+	if !ok {
+		cached = timeseries.New[float64]()
+	}
+
+	// Fetch the missing closed points and the still-open tail, that can't be cached
+	lastClosed := params.Period.RoundTime(c.now()).Add(-params.Period.Duration())
+	fetched := timeseries.New[float64]()
+	for _, r := range rangesToFetch(params, cached, lastClosed) {
+		data, err := c.fetch(ctx, key, params, r, lastClosed, opts...)
+		if err != nil {
+			return api.ListWorkflowResults{}, err
+		}
+
+		for _, p := range data {
+			fetched.Set(p.Time, p.Value)
+		}
+	}
+
+	// Assemble the results
+	ts := cached.Extract(params.Start, params.End, 0)
+	if err := ts.Merge(*fetched, nil); err != nil {
+		return api.ListWorkflowResults{}, err
+	}
+
+	data := make([]api.SMADataPoint, 0, ts.Len())
+	_ = ts.Loop(func(t time.Time, v float64) (bool, error) {
+		data = append(data, api.SMADataPoint{Time: t, Value: v})
+		return false, nil
+	})
+
+	return params.FormatResults(data), nil
+}
+
+// fetch requests the points of the range to the underlying client and caches
+// them. Concurrent calls for the same series and range wait for the first one
+// instead of requesting the points again.
+func (c *cachedClient) fetch(
+	ctx context.Context,
+	key CacheKey,
+	params api.ListWorkflowParams,
+	r timeseries.TimeRange,
+	lastClosed time.Time,
+	opts ...WorkflowOption,
+) ([]api.SMADataPoint, error) {
+	flightKey := fmt.Sprintf("%+v/%d/%d", key, r.Start.Unix(), r.End.Unix())
+	res, err, _ := c.flights.Do(flightKey, func() (interface{}, error) {
+		params.Start = r.Start
+		params.End = r.End
+		params.Format = api.ResultFormatPoints
+
+		res, err := c.Client.List(ctx, params, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		fetched := timeseries.New[float64]()
+		for _, p := range res.Data {
+			fetched.Set(p.Time, p.Value)
+		}
+		if fetched.Len() > 0 {
+			c.store(key, fetched, lastClosed)
+		}
+
+		return res.Data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]api.SMADataPoint), nil
+}
+
+// rangesToFetch returns the ranges of the request that are not in the cached
+// series: every hole among the closed periods, then the periods after the
+// last closed one.
+func rangesToFetch(
+	params api.ListWorkflowParams,
+	cached *timeseries.TimeSerie[float64],
+	lastClosed time.Time,
+) []timeseries.TimeRange {
+	interval := params.Period.Duration()
+	ranges := cached.GetMissingRanges(params.Start, earliest(params.End, lastClosed), interval, 0)
+
+	tailStart := lastClosed.Add(interval)
+	if tailStart.Before(params.Start) {
+		tailStart = params.Start
+	}
+	if tailStart.After(params.End) {
+		return ranges
+	}
+
+	// Extend the last hole if it is just before the tail
+	if len(ranges) > 0 && ranges[len(ranges)-1].End.Add(interval).Equal(tailStart) {
+		ranges[len(ranges)-1].End = params.End
+		return ranges
+	}
+	return append(ranges, timeseries.TimeRange{Start: tailStart, End: params.End})
+}
+
+// store merges the valid points of closed periods into the cached series.
+func (c *cachedClient) store(key CacheKey, fetched *timeseries.TimeSerie[float64], lastClosed time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Copy the cached series as the backend must not see it modified
+	ts := timeseries.New[float64]()
+	if cached, ok := c.backend.Get(key); ok {
+		_ = ts.Merge(*cached, nil)
+	}
+
+	_ = fetched.Loop(func(t time.Time, v float64) (bool, error) {
+		if t.After(lastClosed) {
+			return true, nil
+		}
+		// Zero is the value of the points that could not be computed
+		if v != 0 && !math.IsNaN(v) && !math.IsInf(v, 0) {
+			ts.Set(t, v)
+		}
+		return false, nil
+	})
+
+	c.backend.Set(key, ts)
+}
+
+// Invalidate calls the invalidate workflow and removes the corresponding
+// series from the cache. They are removed before the call so that they are
+// not served anymore, and again after its success to drop the points cached
+// from requests made during the invalidation.
+func (c *cachedClient) Invalidate(
+	ctx context.Context,
+	params api.InvalidateWorkflowParams,
+	opts ...WorkflowOption,
+) (api.InvalidateWorkflowResults, error) {
+	c.delete(params)
+
+	res, err := c.Client.Invalidate(ctx, params, opts...)
+	if err != nil {
+		return api.InvalidateWorkflowResults{}, err
+	}
+
+	c.delete(params)
+	return res, nil
+}
+
+// delete removes the series affected by the invalidation from the cache.
+func (c *cachedClient) delete(params api.InvalidateWorkflowParams) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	keys := make([]CacheKey, 0)
+	for _, k := range c.backend.Keys() {
+		if k.Exchange == params.Exchange && k.Pair == params.Pair && k.Period == params.Period {
+			keys = append(keys, k)
+		}
+	}
+	c.backend.Delete(keys...)
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+type lruEntry struct {
+	key CacheKey
+	ts  *timeseries.TimeSerie[float64]
+}
+
+// LRUCache is an in-memory cache backend evicting the least recently used
+// series when the count of cached points exceeds its size.
+type LRUCache struct {
+	mutex   sync.Mutex
+	size    int
+	points  int
+	order   *list.List
+	entries map[CacheKey]*list.Element
+}
+
+// NewLRUCache creates an in-memory cache backend holding at most size points.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[CacheKey]*list.Element),
+	}
+}
+
+// Get returns the series corresponding to the key, if any.
+func (c *LRUCache) Get(key CacheKey) (*timeseries.TimeSerie[float64], bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+
+	return e.Value.(*lruEntry).ts, true
+}
+
+// Set replaces the series corresponding to the key.
+func (c *LRUCache) Set(key CacheKey, ts *timeseries.TimeSerie[float64]) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.delete(key)
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, ts: ts})
+	c.points += ts.Len()
+
+	// Evict the least recently used series, but keep the last one set
+	for c.points > c.size && c.order.Len() > 1 {
+		c.delete(c.order.Back().Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the series corresponding to the keys.
+func (c *LRUCache) Delete(keys ...CacheKey) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, k := range keys {
+		c.delete(k)
+	}
+}
+
+func (c *LRUCache) delete(key CacheKey) {
+	e, ok := c.entries[key]
+	if !ok {
+		return
+	}
+
+	c.points -= e.Value.(*lruEntry).ts.Len()
+	c.order.Remove(e)
+	delete(c.entries, key)
+}
+
+// Keys returns the keys of the cached series.
+func (c *LRUCache) Keys() []CacheKey {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	keys := make([]CacheKey, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Points returns the count of cached points.
+func (c *LRUCache) Points() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.points
+}
@@ -0,0 +1,362 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/timeseries"
+	"github.com/stretchr/testify/suite"
+)
+
+// recordingClient records the list requests made to the underlying client.
+type recordingClient struct {
+	Client
+	requests []api.ListWorkflowParams
+}
+
+func (c *recordingClient) List(
+	ctx context.Context,
+	params api.ListWorkflowParams,
+	opts ...WorkflowOption,
+) (api.ListWorkflowResults, error) {
+	c.requests = append(c.requests, params)
+	return c.Client.List(ctx, params, opts...)
+}
+
+// staticClient returns the same results to every list request.
+type staticClient struct {
+	Client
+	res api.ListWorkflowResults
+}
+
+func (c staticClient) List(
+	_ context.Context,
+	_ api.ListWorkflowParams,
+	_ ...WorkflowOption,
+) (api.ListWorkflowResults, error) {
+	return c.res, nil
+}
+
+// blockingClient counts the list requests and holds them until released.
+type blockingClient struct {
+	Client
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingClient) List(
+	ctx context.Context,
+	params api.ListWorkflowParams,
+	opts ...WorkflowOption,
+) (api.ListWorkflowResults, error) {
+	if c.calls.Add(1) == 1 {
+		close(c.started)
+	}
+	<-c.release
+	return c.Client.List(ctx, params, opts...)
+}
+
+// invalidatingClient runs a hook while the invalidation is in progress.
+type invalidatingClient struct {
+	Client
+	during func()
+}
+
+func (c invalidatingClient) Invalidate(
+	ctx context.Context,
+	params api.InvalidateWorkflowParams,
+	opts ...WorkflowOption,
+) (api.InvalidateWorkflowResults, error) {
+	c.during()
+	return c.Client.Invalidate(ctx, params, opts...)
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
+
+type CacheSuite struct {
+	suite.Suite
+	next   *recordingClient
+	client *cachedClient
+}
+
+func (suite *CacheSuite) SetupTest() {
+	l, err := ReadCSV(strings.NewReader(localTestCSV), "exchange", "ETH-USDC", period.M1)
+	suite.Require().NoError(err)
+	source, err := NewMemorySource(l)
+	suite.Require().NoError(err)
+
+	suite.next = &recordingClient{Client: NewLocal(source)}
+	suite.client = NewCached(suite.next).(*cachedClient)
+	// The candlestick at 00:03 is the last closed one
+	suite.client.now = func() time.Time { return time.Unix(250, 0) }
+}
+
+func (suite *CacheSuite) params(start, end int64) api.ListWorkflowParams {
+	return api.ListWorkflowParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDC",
+		Period:       period.M1,
+		Start:        time.Unix(start, 0),
+		End:          time.Unix(end, 0),
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+	}
+}
+
+func (suite *CacheSuite) TestListClosedPeriods() {
+	// First call is passed through
+	res, err := suite.client.List(context.Background(), suite.params(120, 180))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Data, 2)
+	suite.Require().Len(suite.next.requests, 1)
+
+	// Second call is served from the cache
+	res2, err := suite.client.List(context.Background(), suite.params(150, 180))
+	suite.Require().NoError(err)
+	suite.Require().Equal(res.Data, res2.Data)
+	suite.Require().Len(suite.next.requests, 1)
+}
+
+func (suite *CacheSuite) TestListOpenTail() {
+	_, err := suite.client.List(context.Background(), suite.params(120, 180))
+	suite.Require().NoError(err)
+
+	// Only the open period is requested
+	_, err = suite.client.List(context.Background(), suite.params(120, 240))
+	suite.Require().NoError(err)
+	suite.Require().Len(suite.next.requests, 2)
+	suite.Require().WithinDuration(time.Unix(240, 0), suite.next.requests[1].Start, 0)
+
+	// The open period is not cached
+	_, err = suite.client.List(context.Background(), suite.params(240, 240))
+	suite.Require().NoError(err)
+	suite.Require().Len(suite.next.requests, 3)
+}
+
+func (suite *CacheSuite) TestListMissingHead() {
+	_, err := suite.client.List(context.Background(), suite.params(180, 180))
+	suite.Require().NoError(err)
+
+	// Only the missing head is requested
+	res, err := suite.client.List(context.Background(), suite.params(120, 180))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Data, 2)
+	suite.Require().Len(suite.next.requests, 2)
+	suite.Require().WithinDuration(time.Unix(120, 0), suite.next.requests[1].Start, 0)
+}
+
+func (suite *CacheSuite) TestListHoles() {
+	cases := []struct {
+		Name     string
+		Cached   []int64
+		Expected []timeseries.TimeRange
+	}{
+		{
+			Name:   "middle hole",
+			Cached: []int64{0, 180},
+			Expected: []timeseries.TimeRange{
+				{Start: time.Unix(60, 0), End: time.Unix(120, 0)},
+			},
+		},
+		{
+			Name:   "tail hole",
+			Cached: []int64{0, 60},
+			Expected: []timeseries.TimeRange{
+				{Start: time.Unix(120, 0), End: time.Unix(180, 0)},
+			},
+		},
+		{
+			Name:   "several holes",
+			Cached: []int64{0, 120},
+			Expected: []timeseries.TimeRange{
+				{Start: time.Unix(60, 0), End: time.Unix(60, 0)},
+				{Start: time.Unix(180, 0), End: time.Unix(180, 0)},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		suite.SetupTest()
+		params := suite.params(0, 180)
+		params.PeriodNumber = 1
+		expected, err := suite.next.Client.List(context.Background(), params)
+		suite.Require().NoError(err, c.Name)
+		suite.Require().Len(expected.Data, 4, c.Name)
+
+		// GIVEN cached points with holes
+		cached := timeseries.New[float64]()
+		for _, p := range expected.Data {
+			if slices.Contains(c.Cached, p.Time.Unix()) {
+				cached.Set(p.Time, p.Value)
+			}
+		}
+		suite.client.backend.Set(CacheKey{
+			Exchange:     params.Exchange,
+			Pair:         params.Pair,
+			Period:       params.Period,
+			PeriodNumber: params.PeriodNumber,
+			PriceType:    params.PriceType,
+		}, cached)
+
+		// WHEN listing the range
+		res, err := suite.client.List(context.Background(), params)
+
+		// THEN only the holes are requested and merged with the cached points
+		suite.Require().NoError(err, c.Name)
+		suite.Require().Equal(expected.Data, res.Data, c.Name)
+		suite.Require().Len(suite.next.requests, len(c.Expected), c.Name)
+		for i, r := range c.Expected {
+			suite.Require().WithinDuration(r.Start, suite.next.requests[i].Start, 0, c.Name)
+			suite.Require().WithinDuration(r.End, suite.next.requests[i].End, 0, c.Name)
+		}
+
+		// THEN the holes are now cached
+		_, err = suite.client.List(context.Background(), params)
+		suite.Require().NoError(err, c.Name)
+		suite.Require().Len(suite.next.requests, len(c.Expected), c.Name)
+	}
+}
+
+func (suite *CacheSuite) TestListInvalidValues() {
+	// GIVEN an underlying client returning invalid values
+	suite.client.Client = staticClient{res: api.ListWorkflowResults{Data: []api.SMADataPoint{
+		{Time: time.Unix(0, 0), Value: 0},
+		{Time: time.Unix(60, 0), Value: math.NaN()},
+		{Time: time.Unix(120, 0), Value: math.Inf(1)},
+		{Time: time.Unix(180, 0), Value: 42},
+	}}}
+
+	// WHEN listing the range
+	params := suite.params(0, 180)
+	res, err := suite.client.List(context.Background(), params)
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Data, 4)
+
+	// THEN only the valid value is cached
+	cached, ok := suite.client.backend.Get(CacheKey{
+		Exchange:     params.Exchange,
+		Pair:         params.Pair,
+		Period:       params.Period,
+		PeriodNumber: params.PeriodNumber,
+		PriceType:    params.PriceType,
+	})
+	suite.Require().True(ok)
+	suite.Require().Equal(1, cached.Len())
+	_, ok = cached.Get(time.Unix(180, 0))
+	suite.Require().True(ok)
+}
+
+func (suite *CacheSuite) TestInvalidate() {
+	_, err := suite.client.List(context.Background(), suite.params(120, 180))
+	suite.Require().NoError(err)
+
+	_, err = suite.client.Invalidate(context.Background(), api.InvalidateWorkflowParams{
+		Exchange: "exchange",
+		Pair:     "ETH-USDC",
+		Period:   period.M1,
+		Start:    time.Unix(0, 0),
+		End:      time.Unix(180, 0),
+	})
+	suite.Require().NoError(err)
+	suite.Require().Empty(suite.client.backend.Keys())
+}
+
+func (suite *CacheSuite) TestInvalidateDuringList() {
+	// GIVEN a list cached while the invalidation is in progress
+	var client *cachedClient
+	client = NewCached(invalidatingClient{
+		Client: suite.next,
+		during: func() {
+			_, err := client.List(context.Background(), suite.params(120, 180))
+			suite.Require().NoError(err)
+			suite.Require().NotEmpty(client.backend.Keys())
+		},
+	}).(*cachedClient)
+	client.now = suite.client.now
+
+	// WHEN the invalidation succeeds
+	_, err := client.Invalidate(context.Background(), api.InvalidateWorkflowParams{
+		Exchange: "exchange",
+		Pair:     "ETH-USDC",
+		Period:   period.M1,
+		Start:    time.Unix(0, 0),
+		End:      time.Unix(180, 0),
+	})
+	suite.Require().NoError(err)
+
+	// THEN the points cached meanwhile are removed too
+	suite.Require().Empty(client.backend.Keys())
+}
+
+func (suite *CacheSuite) TestListConcurrentMisses() {
+	next := &blockingClient{
+		Client:  suite.next,
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	client := NewCached(next).(*cachedClient)
+	client.now = suite.client.now
+
+	// WHEN two identical requests miss the cache at the same time
+	var wg sync.WaitGroup
+	results := make([]api.ListWorkflowResults, 2)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := client.List(context.Background(), suite.params(120, 180))
+			suite.Require().NoError(err)
+			results[i] = res
+		}()
+		if i == 0 {
+			<-next.started
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	// THEN the underlying client is called once and both get the points
+	suite.Require().Equal(int32(1), next.calls.Load())
+	suite.Require().Len(results[0].Data, 2)
+	suite.Require().Equal(results[0], results[1])
+}
+
+func (suite *CacheSuite) TestLRUCache() {
+	cache := NewLRUCache(3)
+	ts := func(n int) *timeseries.TimeSerie[float64] {
+		s := timeseries.New[float64]()
+		for i := 0; i < n; i++ {
+			s.Set(time.Unix(int64(i*60), 0), float64(i))
+		}
+		return s
+	}
+	a, b, c := CacheKey{Pair: "A-B"}, CacheKey{Pair: "B-C"}, CacheKey{Pair: "C-D"}
+
+	cache.Set(a, ts(1))
+	cache.Set(b, ts(1))
+	_, _ = cache.Get(a)
+	cache.Set(c, ts(2))
+
+	// b is the least recently used and is evicted
+	_, ok := cache.Get(b)
+	suite.Require().False(ok)
+	_, ok = cache.Get(a)
+	suite.Require().True(ok)
+	suite.Require().Equal(3, cache.Points())
+}
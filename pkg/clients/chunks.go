@@ -0,0 +1,57 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cryptellation/sma/api"
+	"go.temporal.io/sdk/temporal"
+)
+
+// DefaultListChunkSize is the default count of points requested at once by
+// ListChunks.
+const DefaultListChunkSize = 1000
+
+// ListChunks lists the SMA points of the range chunk by chunk of at most
+// chunkSize points (DefaultListChunkSize if not positive), so that large
+// ranges are not loaded at once, and calls fn with the parameters and the
+// results of each chunk, in order. The results are always points.
+func ListChunks(
+	ctx context.Context,
+	cl Client,
+	params api.ListWorkflowParams,
+	chunkSize int,
+	fn func(params api.ListWorkflowParams, res api.ListWorkflowResults) error,
+	opts ...WorkflowOption,
+) error {
+	if err := params.Validate(); err != nil {
+		return temporal.NewNonRetryableApplicationError(err.Error(), api.ErrTypeInvalidArgument, err)
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultListChunkSize
+	}
+	params.Format = api.ResultFormatPoints
+
+	chunk := params.Period.Duration() * time.Duration(chunkSize)
+	for start := params.Period.RoundTime(params.Start); !start.After(params.End); start = start.Add(chunk) {
+		chunkParams := params
+		chunkParams.Start = start
+		chunkParams.End = start.Add(chunk - params.Period.Duration())
+		if chunkParams.End.After(params.End) {
+			chunkParams.End = params.End
+		}
+
+		res, err := cl.List(ctx, chunkParams, opts...)
+		if err != nil {
+			return fmt.Errorf("listing from %s to %s: %w",
+				chunkParams.Start.Format(time.RFC3339), chunkParams.End.Format(time.RFC3339), err)
+		}
+
+		if err := fn(chunkParams, res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
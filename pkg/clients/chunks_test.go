@@ -0,0 +1,92 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestChunksSuite(t *testing.T) {
+	suite.Run(t, new(ChunksSuite))
+}
+
+type ChunksSuite struct {
+	suite.Suite
+	params api.ListWorkflowParams
+}
+
+func (suite *ChunksSuite) SetupTest() {
+	suite.params = api.ListWorkflowParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDC",
+		Period:       period.M1,
+		Start:        time.Unix(130, 0),
+		End:          time.Unix(420, 0),
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		Format:       api.ResultFormatCompact,
+	}
+}
+
+func (suite *ChunksSuite) TestListChunks() {
+	cl := &scriptedClient{responses: []func(api.ListWorkflowParams) (api.ListWorkflowResults, error){pointsOf}}
+
+	// WHEN listing the range by chunks of 2 points
+	var times []int64
+	err := ListChunks(context.Background(), cl, suite.params, 2,
+		func(params api.ListWorkflowParams, res api.ListWorkflowResults) error {
+			suite.Require().Equal(params.Start, res.Data[0].Time)
+			for _, p := range res.Data {
+				times = append(times, p.Time.Unix())
+			}
+			return nil
+		})
+
+	// THEN the points are requested by chunks, in order
+	suite.Require().NoError(err)
+	suite.Require().Equal([]int64{120, 180, 240, 300, 360, 420}, times)
+	suite.Require().Len(cl.requests, 3)
+	for _, r := range cl.requests {
+		suite.Require().Equal(api.ResultFormatPoints, r.Format)
+	}
+}
+
+func (suite *ChunksSuite) TestListChunksErrors() {
+	expected := errors.New("error")
+	failing := func(api.ListWorkflowParams) (api.ListWorkflowResults, error) {
+		return api.ListWorkflowResults{}, expected
+	}
+
+	// List error is returned with the chunk range
+	cl := &scriptedClient{responses: []func(api.ListWorkflowParams) (api.ListWorkflowResults, error){
+		pointsOf, failing,
+	}}
+	err := ListChunks(context.Background(), cl, suite.params, 2,
+		func(api.ListWorkflowParams, api.ListWorkflowResults) error { return nil })
+	suite.Require().ErrorIs(err, expected)
+	suite.Require().ErrorContains(err, "1970-01-01T00:04:00Z to 1970-01-01T00:05:00Z")
+
+	// Callback error stops the listing
+	cl = &scriptedClient{responses: []func(api.ListWorkflowParams) (api.ListWorkflowResults, error){pointsOf}}
+	err = ListChunks(context.Background(), cl, suite.params, 2,
+		func(api.ListWorkflowParams, api.ListWorkflowResults) error { return expected })
+	suite.Require().ErrorIs(err, expected)
+	suite.Require().Len(cl.requests, 1)
+
+	// Invalid parameters are not requested
+	cl = &scriptedClient{}
+	suite.params.Period = "invalid"
+	err = ListChunks(context.Background(), cl, suite.params, 2,
+		func(api.ListWorkflowParams, api.ListWorkflowResults) error { return nil })
+	suite.Require().True(IsInvalidArgument(err), err)
+	suite.Require().Empty(cl.requests)
+}
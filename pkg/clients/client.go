@@ -2,36 +2,163 @@ package clients
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/tracing"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
 	temporalclient "go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
 )
 
 // Client is a client for the cryptellation sma service.
 type Client interface {
 	// List calls the list workflow.
-	List(ctx context.Context, params api.ListWorkflowParams) (api.ListWorkflowResults, error)
+	List(ctx context.Context, params api.ListWorkflowParams, opts ...WorkflowOption) (api.ListWorkflowResults, error)
+	// StartList starts the list workflow without waiting for its results.
+	StartList(ctx context.Context, params api.ListWorkflowParams, opts ...WorkflowOption) (ListHandle, error)
+	// GetListResult waits for the results of a list workflow started with StartList.
+	GetListResult(ctx context.Context, handle ListHandle) (api.ListWorkflowResults, error)
+	// PollList returns the results of a list workflow started with StartList
+	// if it is over, without waiting for it otherwise.
+	PollList(ctx context.Context, handle ListHandle) (res api.ListWorkflowResults, done bool, err error)
+	// Invalidate calls the invalidate workflow.
+	Invalidate(
+		ctx context.Context,
+		params api.InvalidateWorkflowParams,
+		opts ...WorkflowOption,
+	) (api.InvalidateWorkflowResults, error)
+	// Export calls the export workflow, that writes the selected series to a
+	// file on the worker. It fails with ErrExportInProgress if an export of
+	// the same file is running.
+	Export(
+		ctx context.Context,
+		params api.ExportWorkflowParams,
+		opts ...WorkflowOption,
+	) (api.ExportWorkflowResults, error)
+	// Verify calls the verify workflow, that compares the cached points with
+	// a fresh computation.
+	Verify(
+		ctx context.Context,
+		params api.VerifyWorkflowParams,
+		opts ...WorkflowOption,
+	) (api.VerifyWorkflowResults, error)
+	// ListSeries calls the list series workflow.
+	ListSeries(ctx context.Context, opts ...WorkflowOption) (api.ListSeriesWorkflowResults, error)
 	// Info calls the service info.
-	Info(ctx context.Context) (api.ServiceInfoResults, error)
+	Info(ctx context.Context, opts ...WorkflowOption) (api.ServiceInfoResults, error)
 }
 
 type client struct {
-	temporal temporalclient.Client
+	temporal         temporalclient.Client
+	taskQueue        string
+	executionTimeout time.Duration
+	runTimeout       time.Duration
+	retryPolicy      *temporal.RetryPolicy
+}
+
+// Option is an option of the client.
+type Option func(c *client)
+
+// WithTaskQueue sets the task queue on which the workflows are started.
+func WithTaskQueue(taskQueue string) Option {
+	return func(c *client) {
+		c.taskQueue = taskQueue
+	}
+}
+
+// WithNamespace sets the task queue to the one of the SMA deployment running
+// in the given namespace (see api.TaskQueueName).
+func WithNamespace(namespace string) Option {
+	return func(c *client) {
+		c.taskQueue = api.TaskQueueName(namespace)
+	}
+}
+
+// WithDefaultExecutionTimeout sets the execution timeout applied to every
+// workflow started by the client, so a call fails instead of hanging when no
+// worker picks it up.
+func WithDefaultExecutionTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.executionTimeout = timeout
+	}
+}
+
+// WithDefaultRunTimeout sets the run timeout applied to every workflow started
+// by the client.
+func WithDefaultRunTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.runTimeout = timeout
+	}
+}
+
+// WithDefaultRetryPolicy sets the retry policy applied to every workflow
+// started by the client.
+func WithDefaultRetryPolicy(policy *temporal.RetryPolicy) Option {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
 }
 
 // New creates a new client to execute temporal workflows.
-func New(cl temporalclient.Client) Client {
-	return &client{temporal: cl}
+func New(cl temporalclient.Client, opts ...Option) Client {
+	c := &client{
+		temporal:  cl,
+		taskQueue: api.WorkerTaskQueueName,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// failIfRunning makes the start of a workflow fail if an execution with the
+// same ID is running, instead of attaching to it.
+func failIfRunning(o *temporalclient.StartWorkflowOptions) {
+	o.WorkflowIDConflictPolicy = enumspb.WORKFLOW_ID_CONFLICT_POLICY_FAIL
+	o.WorkflowExecutionErrorWhenAlreadyStarted = true
+}
+
+// workflowOptions returns the options to start a workflow with the client
+// defaults, overridden by the call options. If id is not empty, it is prefixed
+// with the task queue, so that deployments sharing a Temporal namespace never
+// attach to each other's executions, and a running execution with that ID is
+// reused instead of starting a new one.
+func (c client) workflowOptions(id string, opts ...WorkflowOption) temporalclient.StartWorkflowOptions {
+	options := temporalclient.StartWorkflowOptions{
+		TaskQueue:                c.taskQueue,
+		WorkflowExecutionTimeout: c.executionTimeout,
+		WorkflowRunTimeout:       c.runTimeout,
+		RetryPolicy:              c.retryPolicy,
+	}
+
+	if id != "" {
+		options.ID = c.taskQueue + "/" + id
+		options.WorkflowIDReusePolicy = enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE
+		options.WorkflowIDConflictPolicy = enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
 }
 
 // List calls the list workflow.
 func (c client) List(
 	ctx context.Context,
 	params api.ListWorkflowParams,
+	opts ...WorkflowOption,
 ) (res api.ListWorkflowResults, err error) {
-	workflowOptions := temporalclient.StartWorkflowOptions{
-		TaskQueue: api.WorkerTaskQueueName,
-	}
+	workflowOptions := c.workflowOptions(ListWorkflowID(params), opts...)
+
+	// Trace the whole call, as the execution spans only cover the workflow
+	// start on the client side
+	ctx, end := tracing.StartSpan(ctx, api.ListWorkflowName)
+	defer func() { end(err) }()
 
 	// Execute workflow
 	exec, err := c.temporal.ExecuteWorkflow(ctx, workflowOptions, api.ListWorkflowName, params)
@@ -44,12 +171,91 @@ func (c client) List(
 	return res, err
 }
 
-// Info calls the service info.
-func (c client) Info(ctx context.Context) (res api.ServiceInfoResults, err error) {
-	workflowOptions := temporalclient.StartWorkflowOptions{
-		TaskQueue: api.WorkerTaskQueueName,
+// Invalidate calls the invalidate workflow.
+func (c client) Invalidate(
+	ctx context.Context,
+	params api.InvalidateWorkflowParams,
+	opts ...WorkflowOption,
+) (res api.InvalidateWorkflowResults, err error) {
+	workflowOptions := c.workflowOptions(InvalidateWorkflowID(params), opts...)
+
+	// Execute workflow
+	exec, err := c.temporal.ExecuteWorkflow(ctx, workflowOptions, api.InvalidateWorkflowName, params)
+	if err != nil {
+		return api.InvalidateWorkflowResults{}, err
 	}
 
+	// Get result and return
+	err = exec.Get(ctx, &res)
+	return res, err
+}
+
+// Export calls the export workflow.
+func (c client) Export(
+	ctx context.Context,
+	params api.ExportWorkflowParams,
+	opts ...WorkflowOption,
+) (res api.ExportWorkflowResults, err error) {
+	// Never attach to a running export, that may have other filters
+	opts = append([]WorkflowOption{failIfRunning}, opts...)
+	workflowOptions := c.workflowOptions(ExportWorkflowID(params), opts...)
+
+	// Execute workflow
+	exec, err := c.temporal.ExecuteWorkflow(ctx, workflowOptions, api.ExportWorkflowName, params)
+	var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+	if errors.As(err, &alreadyStarted) {
+		return api.ExportWorkflowResults{}, fmt.Errorf("%w: %s", ErrExportInProgress, params.FileName)
+	} else if err != nil {
+		return api.ExportWorkflowResults{}, err
+	}
+
+	// Get result and return
+	err = exec.Get(ctx, &res)
+	return res, err
+}
+
+// Verify calls the verify workflow.
+func (c client) Verify(
+	ctx context.Context,
+	params api.VerifyWorkflowParams,
+	opts ...WorkflowOption,
+) (res api.VerifyWorkflowResults, err error) {
+	workflowOptions := c.workflowOptions("", opts...)
+
+	// Execute workflow
+	exec, err := c.temporal.ExecuteWorkflow(ctx, workflowOptions, api.VerifyWorkflowName, params)
+	if err != nil {
+		return api.VerifyWorkflowResults{}, err
+	}
+
+	// Get result and return
+	err = exec.Get(ctx, &res)
+	return res, err
+}
+
+// ListSeries calls the list series workflow.
+func (c client) ListSeries(
+	ctx context.Context,
+	opts ...WorkflowOption,
+) (res api.ListSeriesWorkflowResults, err error) {
+	workflowOptions := c.workflowOptions("", opts...)
+
+	// Execute workflow
+	exec, err := c.temporal.ExecuteWorkflow(ctx, workflowOptions, api.ListSeriesWorkflowName,
+		api.ListSeriesWorkflowParams{})
+	if err != nil {
+		return api.ListSeriesWorkflowResults{}, err
+	}
+
+	// Get result and return
+	err = exec.Get(ctx, &res)
+	return res, err
+}
+
+// Info calls the service info.
+func (c client) Info(ctx context.Context, opts ...WorkflowOption) (res api.ServiceInfoResults, err error) {
+	workflowOptions := c.workflowOptions("", opts...)
+
 	// Execute workflow
 	exec, err := c.temporal.ExecuteWorkflow(ctx, workflowOptions, api.ServiceInfoWorkflowName)
 	if err != nil {
@@ -0,0 +1,147 @@
+package clients
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cryptellation/sma/api"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/proto"
+)
+
+// PayloadEncoding is the encoding used for the SMA workflows payloads.
+type PayloadEncoding string
+
+const (
+	// PayloadEncodingJSON encodes the payloads in JSON (default).
+	PayloadEncodingJSON PayloadEncoding = "json"
+	// PayloadEncodingProtobuf encodes the payloads in binary protobuf.
+	PayloadEncodingProtobuf PayloadEncoding = "protobuf"
+)
+
+// Validate checks that the payload encoding is supported.
+func (e PayloadEncoding) Validate() error {
+	switch e {
+	case PayloadEncodingJSON, PayloadEncodingProtobuf:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownPayloadEncoding, string(e))
+	}
+}
+
+// NewDataConverter creates a temporal data converter encoding the SMA workflows
+// payloads with the given encoding. Whatever the encoding, the converter decodes
+// both JSON and protobuf payloads, so the encoding can be switched on clients and
+// workers independently during a rollout.
+//
+// It should be set in the temporal client options of both the callers and the worker.
+func NewDataConverter(encoding PayloadEncoding) (converter.DataConverter, error) {
+	if err := encoding.Validate(); err != nil {
+		return nil, err
+	}
+
+	return converter.NewCompositeDataConverter(
+		converter.NewNilPayloadConverter(),
+		converter.NewByteSlicePayloadConverter(),
+		converter.NewProtoJSONPayloadConverter(),
+		&protoPayloadConverter{
+			ProtoPayloadConverter: converter.NewProtoPayloadConverter(),
+			encodeAPIPayloads:     encoding == PayloadEncodingProtobuf,
+		},
+		&jsonPayloadConverter{
+			JSONPayloadConverter: converter.NewJSONPayloadConverter(),
+		},
+	), nil
+}
+
+// jsonPayloadConverter is a JSON payload converter that also sets and checks
+// the schema version of the SMA API payloads.
+type jsonPayloadConverter struct {
+	*converter.JSONPayloadConverter
+}
+
+// ToPayload converts a single value to a payload.
+func (c *jsonPayloadConverter) ToPayload(value interface{}) (*commonpb.Payload, error) {
+	payload, err := c.JSONPayloadConverter.ToPayload(value)
+	if err != nil || payload == nil {
+		return payload, err
+	}
+
+	if _, ok := value.(api.ProtoConvertible); ok {
+		setSchemaVersion(payload)
+	}
+	return payload, nil
+}
+
+// FromPayload converts a single value from a payload.
+func (c *jsonPayloadConverter) FromPayload(payload *commonpb.Payload, valuePtr interface{}) error {
+	if _, ok := valuePtr.(api.ProtoLoadable); ok {
+		if err := checkSchemaVersion(payload); err != nil {
+			return err
+		}
+	}
+	return c.JSONPayloadConverter.FromPayload(payload, valuePtr)
+}
+
+// setSchemaVersion sets the schema version in the payload metadata.
+func setSchemaVersion(payload *commonpb.Payload) {
+	if payload.Metadata == nil {
+		payload.Metadata = make(map[string][]byte)
+	}
+	payload.Metadata[api.SchemaVersionMetadataKey] = []byte(strconv.Itoa(api.SchemaVersion))
+}
+
+// checkSchemaVersion checks that the schema version of the payload can be decoded.
+func checkSchemaVersion(payload *commonpb.Payload) error {
+	err := api.CheckSchemaVersion(string(payload.GetMetadata()[api.SchemaVersionMetadataKey]))
+	if err != nil {
+		return fmt.Errorf("%w: %w", converter.ErrUnableToDecode, err)
+	}
+	return nil
+}
+
+// protoPayloadConverter is a protobuf payload converter that also converts the
+// SMA API payloads through their protobuf representation.
+type protoPayloadConverter struct {
+	*converter.ProtoPayloadConverter
+	encodeAPIPayloads bool
+}
+
+// ToPayload converts a single value to a payload.
+func (c *protoPayloadConverter) ToPayload(value interface{}) (*commonpb.Payload, error) {
+	if pc, ok := value.(api.ProtoConvertible); ok {
+		if !c.encodeAPIPayloads {
+			// Let the JSON converter encode it
+			return nil, nil
+		}
+		payload, err := c.ProtoPayloadConverter.ToPayload(pc.ToProto())
+		if err != nil {
+			return nil, err
+		}
+		setSchemaVersion(payload)
+		return payload, nil
+	}
+
+	return c.ProtoPayloadConverter.ToPayload(value)
+}
+
+// FromPayload converts a single value from a payload.
+func (c *protoPayloadConverter) FromPayload(payload *commonpb.Payload, valuePtr interface{}) error {
+	pl, ok := valuePtr.(api.ProtoLoadable)
+	if !ok {
+		return c.ProtoPayloadConverter.FromPayload(payload, valuePtr)
+	}
+	if err := checkSchemaVersion(payload); err != nil {
+		return err
+	}
+
+	// Decode the protobuf message corresponding to the payload
+	msg := pl.ToProto()
+	proto.Reset(msg)
+	if err := proto.Unmarshal(payload.GetData(), msg); err != nil {
+		return fmt.Errorf("%w: %w", converter.ErrUnableToDecode, err)
+	}
+
+	return pl.FromProto(msg)
+}
@@ -0,0 +1,157 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/converter"
+)
+
+func TestDataConverterSuite(t *testing.T) {
+	suite.Run(t, new(DataConverterSuite))
+}
+
+type DataConverterSuite struct {
+	suite.Suite
+}
+
+func (suite *DataConverterSuite) TestRoundTrip() {
+	t1 := time.Date(2023, 2, 26, 12, 0, 0, 0, time.UTC)
+	t2 := time.Date(2023, 2, 26, 12, 1, 0, 0, time.UTC)
+	params := api.ListWorkflowParams{
+		Exchange:     "binance",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		Start:        t1,
+		End:          t2,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+	}
+	results := api.ListWorkflowResults{
+		Data: []api.SMADataPoint{{Time: t1, Value: 1603.5}, {Time: t2, Value: 1604}},
+	}
+
+	cases := []struct {
+		Encoding         PayloadEncoding
+		ExpectedEncoding string
+	}{
+		{Encoding: PayloadEncodingJSON, ExpectedEncoding: converter.MetadataEncodingJSON},
+		{Encoding: PayloadEncodingProtobuf, ExpectedEncoding: converter.MetadataEncodingProto},
+	}
+
+	for _, c := range cases {
+		dc, err := NewDataConverter(c.Encoding)
+		suite.Require().NoError(err, c.Encoding)
+
+		// Check params
+		payload, err := dc.ToPayload(params)
+		suite.Require().NoError(err, c.Encoding)
+		suite.Require().Equal(c.ExpectedEncoding, string(payload.Metadata[converter.MetadataEncoding]), c.Encoding)
+		var decodedParams api.ListWorkflowParams
+		suite.Require().NoError(dc.FromPayload(payload, &decodedParams), c.Encoding)
+		suite.Require().Equal(params, decodedParams, c.Encoding)
+
+		// Check results
+		payload, err = dc.ToPayload(results)
+		suite.Require().NoError(err, c.Encoding)
+		var decodedResults api.ListWorkflowResults
+		suite.Require().NoError(dc.FromPayload(payload, &decodedResults), c.Encoding)
+		suite.Require().Equal(results, decodedResults, c.Encoding)
+	}
+}
+
+func (suite *DataConverterSuite) TestMixedEncodings() {
+	jsonDC, err := NewDataConverter(PayloadEncodingJSON)
+	suite.Require().NoError(err)
+	protoDC, err := NewDataConverter(PayloadEncodingProtobuf)
+	suite.Require().NoError(err)
+	results := api.ServiceInfoResults{Version: "v1.0.0"}
+
+	// JSON payloads are decoded by the protobuf converter
+	payload, err := jsonDC.ToPayload(results)
+	suite.Require().NoError(err)
+	var decoded api.ServiceInfoResults
+	suite.Require().NoError(protoDC.FromPayload(payload, &decoded))
+	suite.Require().Equal(results, decoded)
+
+	// Protobuf payloads are decoded by the JSON converter
+	payload, err = protoDC.ToPayload(results)
+	suite.Require().NoError(err)
+	decoded = api.ServiceInfoResults{}
+	suite.Require().NoError(jsonDC.FromPayload(payload, &decoded))
+	suite.Require().Equal(results, decoded)
+
+	// Non API values are still encoded in JSON
+	payload, err = protoDC.ToPayload(map[string]int{"a": 1})
+	suite.Require().NoError(err)
+	suite.Require().Equal(converter.MetadataEncodingJSON, string(payload.Metadata[converter.MetadataEncoding]))
+}
+
+func (suite *DataConverterSuite) TestProtobufIsSmaller() {
+	results := api.ListWorkflowResults{}
+	start := time.Date(2023, 2, 26, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 1000; i++ {
+		results.Data = append(results.Data, api.SMADataPoint{
+			Time:  start.Add(time.Duration(i) * time.Minute),
+			Value: 1600 + float64(i)/3,
+		})
+	}
+
+	jsonDC, err := NewDataConverter(PayloadEncodingJSON)
+	suite.Require().NoError(err)
+	jsonPayload, err := jsonDC.ToPayload(results)
+	suite.Require().NoError(err)
+
+	protoDC, err := NewDataConverter(PayloadEncodingProtobuf)
+	suite.Require().NoError(err)
+	protoPayload, err := protoDC.ToPayload(results)
+	suite.Require().NoError(err)
+
+	suite.Require().Less(len(protoPayload.Data), len(jsonPayload.Data))
+}
+
+func (suite *DataConverterSuite) TestSchemaVersion() {
+	results := api.ServiceInfoResults{Version: "v1.0.0"}
+
+	for _, encoding := range []PayloadEncoding{PayloadEncodingJSON, PayloadEncodingProtobuf} {
+		dc, err := NewDataConverter(encoding)
+		suite.Require().NoError(err, encoding)
+
+		// API payloads carry the schema version
+		payload, err := dc.ToPayload(results)
+		suite.Require().NoError(err, encoding)
+		suite.Require().Equal(strconv.Itoa(api.SchemaVersion),
+			string(payload.Metadata[api.SchemaVersionMetadataKey]), encoding)
+
+		// Payloads without version are from before the version 1
+		delete(payload.Metadata, api.SchemaVersionMetadataKey)
+		var decoded api.ServiceInfoResults
+		suite.Require().NoError(dc.FromPayload(payload, &decoded), encoding)
+		suite.Require().Equal(results, decoded, encoding)
+
+		// Payloads from a newer version are rejected
+		payload.Metadata[api.SchemaVersionMetadataKey] = []byte(strconv.Itoa(api.SchemaVersion + 1))
+		err = dc.FromPayload(payload, &decoded)
+		suite.Require().ErrorIs(err, api.ErrUnsupportedSchemaVersion, encoding)
+	}
+
+	// Non API values do not carry it
+	dc, err := NewDataConverter(PayloadEncodingJSON)
+	suite.Require().NoError(err)
+	payload, err := dc.ToPayload(map[string]int{"a": 1})
+	suite.Require().NoError(err)
+	suite.Require().NotContains(payload.Metadata, api.SchemaVersionMetadataKey)
+}
+
+func (suite *DataConverterSuite) TestUnknownEncoding() {
+	_, err := NewDataConverter("xml")
+	suite.Require().ErrorIs(err, ErrUnknownPayloadEncoding)
+}
@@ -0,0 +1,45 @@
+package clients
+
+import (
+	"errors"
+
+	"github.com/cryptellation/sma/api"
+	"go.temporal.io/sdk/temporal"
+)
+
+var (
+	// ErrUnknownPayloadEncoding is returned when the payload encoding is not supported.
+	ErrUnknownPayloadEncoding = errors.New("unknown payload encoding")
+	// ErrExportInProgress is returned when an export of the same file is
+	// already running.
+	ErrExportInProgress = errors.New("export already in progress")
+)
+
+// IsInvalidArgument returns true if the error has been raised by the service
+// because of invalid parameters.
+func IsInvalidArgument(err error) bool {
+	return hasApplicationErrorType(err, api.ErrTypeInvalidArgument)
+}
+
+// IsNotFound returns true if the error has been raised by the service because
+// of missing data.
+func IsNotFound(err error) bool {
+	return hasApplicationErrorType(err, api.ErrTypeNotFound)
+}
+
+// IsUpstreamUnavailable returns true if the error has been raised by the
+// service because an upstream service is unavailable.
+func IsUpstreamUnavailable(err error) bool {
+	return hasApplicationErrorType(err, api.ErrTypeUpstreamUnavailable)
+}
+
+// IsAlreadyExists returns true if the error has been raised by the service
+// because of an existing resource (e.g. export file) that is not replaced.
+func IsAlreadyExists(err error) bool {
+	return hasApplicationErrorType(err, api.ErrTypeAlreadyExists)
+}
+
+func hasApplicationErrorType(err error, errType string) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.Type() == errType
+}
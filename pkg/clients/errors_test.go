@@ -0,0 +1,38 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
+)
+
+func TestErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsSuite))
+}
+
+type ErrorsSuite struct {
+	suite.Suite
+}
+
+func (suite *ErrorsSuite) TestErrorTypes() {
+	invalid := fmt.Errorf("wrapped: %w",
+		temporal.NewNonRetryableApplicationError("pair is required", api.ErrTypeInvalidArgument, nil))
+	notFound := temporal.NewNonRetryableApplicationError("no candlesticks", api.ErrTypeNotFound, nil)
+	upstream := temporal.NewApplicationError("timeout", api.ErrTypeUpstreamUnavailable, nil)
+	other := errors.New("other")
+
+	suite.Require().True(IsInvalidArgument(invalid))
+	suite.Require().False(IsInvalidArgument(notFound))
+	suite.Require().True(IsNotFound(notFound))
+	suite.Require().False(IsNotFound(upstream))
+	suite.Require().True(IsUpstreamUnavailable(upstream))
+	suite.Require().False(IsUpstreamUnavailable(other))
+	suite.Require().False(IsInvalidArgument(nil))
+}
@@ -0,0 +1,191 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/sma"
+	"github.com/cryptellation/version"
+	temporalclient "go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+)
+
+// ErrUnknownListHandle is returned when a list handle does not correspond to
+// a list started with the client.
+var ErrUnknownListHandle = errors.New("unknown list handle")
+
+// ErrNotSupportedLocally is returned by the local client for the operations
+// that need the SMA service.
+var ErrNotSupportedLocally = errors.New("not supported by the local client")
+
+type localResult struct {
+	res api.ListWorkflowResults
+	err error
+}
+
+type localClient struct {
+	source CandlestickSource
+
+	mutex   sync.Mutex
+	runs    int
+	results map[ListHandle]localResult
+}
+
+// NewLocal creates a client computing the SMA points in process from the
+// candlesticks of the source, without Temporal nor database. It applies the
+// same validation and rounding as the list workflow and is meant for
+// backtesting and research code.
+//
+// Nothing is cached: Invalidate is a no-op, ListSeries returns no series and
+// Export and Verify return ErrNotSupportedLocally.
+func NewLocal(source CandlestickSource) Client {
+	return &localClient{
+		source:  source,
+		results: make(map[ListHandle]localResult),
+	}
+}
+
+// List computes the SMA points.
+func (c *localClient) List(
+	ctx context.Context,
+	params api.ListWorkflowParams,
+	_ ...WorkflowOption,
+) (api.ListWorkflowResults, error) {
+	// Validate and process the params
+	params, err := params.Normalize()
+	if err != nil {
+		return api.ListWorkflowResults{}, temporal.NewNonRetryableApplicationError(
+			err.Error(), api.ErrTypeInvalidArgument, err)
+	}
+
+	// Get necessary candlesticks
+	start := params.Start.Add(-params.Period.Duration() * time.Duration(params.PeriodNumber))
+	list, err := c.source.ListCandlesticks(ctx, params.Exchange, params.Pair, params.Period, start, params.End)
+	if err != nil {
+		err = fmt.Errorf("listing candlesticks: %w", err)
+		return api.ListWorkflowResults{}, temporal.NewApplicationError(
+			err.Error(), api.ErrTypeUpstreamUnavailable, err)
+	} else if len(list) == 0 {
+		err = fmt.Errorf("no candlesticks found for %s %s %s from %s to %s",
+			params.Exchange, params.Pair, params.Period, start, params.End)
+		return api.ListWorkflowResults{}, temporal.NewNonRetryableApplicationError(
+			err.Error(), api.ErrTypeNotFound, err)
+	}
+
+	csList := candlestick.NewList(params.Exchange, params.Pair, params.Period)
+	for _, cs := range list {
+		if err := csList.Set(cs); err != nil {
+			return api.ListWorkflowResults{}, err
+		}
+	}
+
+	// Generate SMAs
+	data, err := sma.DataPoints(sma.TimeSerieParams{
+		Candlesticks: csList,
+		PriceType:    params.PriceType,
+		Start:        params.Start,
+		End:          params.End,
+		PeriodNumber: params.PeriodNumber,
+	})
+	if err != nil {
+		return api.ListWorkflowResults{}, err
+	}
+
+	return params.FormatResults(data), nil
+}
+
+// StartList computes the SMA points and keeps them for GetListResult and PollList.
+func (c *localClient) StartList(
+	ctx context.Context,
+	params api.ListWorkflowParams,
+	opts ...WorkflowOption,
+) (ListHandle, error) {
+	options := temporalclient.StartWorkflowOptions{ID: ListWorkflowID(params)}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	res, err := c.List(ctx, params)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.runs++
+	handle := ListHandle{
+		WorkflowID: options.ID,
+		RunID:      strconv.Itoa(c.runs),
+	}
+	c.results[handle] = localResult{res: res, err: err}
+
+	return handle, nil
+}
+
+// GetListResult returns the results of a list started with StartList. The
+// results are released once returned, so a handle can only be read once.
+func (c *localClient) GetListResult(_ context.Context, handle ListHandle) (api.ListWorkflowResults, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	r, ok := c.results[handle]
+	if !ok {
+		return api.ListWorkflowResults{}, fmt.Errorf("%w: %+v", ErrUnknownListHandle, handle)
+	}
+	delete(c.results, handle)
+
+	return r.res, r.err
+}
+
+// PollList returns the results of a list started with StartList, which is
+// always over as StartList is synchronous.
+func (c *localClient) PollList(
+	ctx context.Context,
+	handle ListHandle,
+) (api.ListWorkflowResults, bool, error) {
+	res, err := c.GetListResult(ctx, handle)
+	return res, true, err
+}
+
+// Invalidate does nothing as the local client has no cache.
+func (c *localClient) Invalidate(
+	_ context.Context,
+	_ api.InvalidateWorkflowParams,
+	_ ...WorkflowOption,
+) (api.InvalidateWorkflowResults, error) {
+	return api.InvalidateWorkflowResults{}, nil
+}
+
+// Export returns an error as the local client has no cache to export.
+func (c *localClient) Export(
+	_ context.Context,
+	_ api.ExportWorkflowParams,
+	_ ...WorkflowOption,
+) (api.ExportWorkflowResults, error) {
+	return api.ExportWorkflowResults{}, fmt.Errorf("export: %w", ErrNotSupportedLocally)
+}
+
+// Verify returns an error as the local client has no cache to verify.
+func (c *localClient) Verify(
+	_ context.Context,
+	_ api.VerifyWorkflowParams,
+	_ ...WorkflowOption,
+) (api.VerifyWorkflowResults, error) {
+	return api.VerifyWorkflowResults{}, fmt.Errorf("verify: %w", ErrNotSupportedLocally)
+}
+
+// ListSeries returns no series as the local client has no cache.
+func (c *localClient) ListSeries(_ context.Context, _ ...WorkflowOption) (api.ListSeriesWorkflowResults, error) {
+	return api.ListSeriesWorkflowResults{}, nil
+}
+
+// Info returns the version of the local client.
+func (c *localClient) Info(_ context.Context, _ ...WorkflowOption) (api.ServiceInfoResults, error) {
+	return api.ServiceInfoResults{
+		Version: version.FullVersion(),
+	}, nil
+}
@@ -0,0 +1,127 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
+)
+
+const localTestCSV = `time,open,high,low,close,volume
+1970-01-01T00:00:00Z,0,0,0,1000,0
+1970-01-01T00:01:00Z,0,0,0,1500,0
+1970-01-01T00:02:00Z,0,0,0,1250,0
+1970-01-01T00:03:00Z,0,0,0,1300,0
+`
+
+func TestLocalSuite(t *testing.T) {
+	suite.Run(t, new(LocalSuite))
+}
+
+type LocalSuite struct {
+	suite.Suite
+	client Client
+}
+
+func (suite *LocalSuite) SetupTest() {
+	l, err := ReadCSV(strings.NewReader(localTestCSV), "exchange", "ETH-USDC", period.M1)
+	suite.Require().NoError(err)
+	suite.Require().Equal(4, l.Data.Len())
+
+	source, err := NewMemorySource(l)
+	suite.Require().NoError(err)
+	suite.client = NewLocal(source)
+}
+
+func (suite *LocalSuite) params() api.ListWorkflowParams {
+	return api.ListWorkflowParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDC",
+		Period:       period.M1,
+		Start:        time.Unix(150, 0), // Rounded to 120
+		End:          time.Unix(180, 0),
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+	}
+}
+
+func (suite *LocalSuite) TestList() {
+	res, err := suite.client.List(context.Background(), suite.params())
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Data, 2)
+	suite.Require().WithinDuration(time.Unix(120, 0), res.Data[0].Time, 0)
+	suite.Require().Equal(1250.0, res.Data[0].Value)
+	suite.Require().WithinDuration(time.Unix(180, 0), res.Data[1].Time, 0)
+	suite.Require().Equal(1350.0, res.Data[1].Value)
+}
+
+func (suite *LocalSuite) TestListCompact() {
+	params := suite.params()
+	params.Format = api.ResultFormatCompact
+
+	res, err := suite.client.List(context.Background(), params)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(res.Compact)
+	suite.Require().Equal([]float64{1250, 1350}, res.Compact.Values)
+}
+
+func (suite *LocalSuite) TestListErrors() {
+	invalid := suite.params()
+	invalid.Pair = "ETHUSDC"
+	_, err := suite.client.List(context.Background(), invalid)
+	suite.Require().True(IsInvalidArgument(err), err)
+
+	unknown := suite.params()
+	unknown.Pair = "BTC-USDC"
+	_, err = suite.client.List(context.Background(), unknown)
+	suite.Require().True(IsNotFound(err), err)
+}
+
+type failingSource struct{}
+
+func (failingSource) ListCandlesticks(
+	_ context.Context,
+	_, _ string,
+	_ period.Symbol,
+	_, _ time.Time,
+) ([]candlestick.Candlestick, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (suite *LocalSuite) TestListUpstreamUnavailable() {
+	_, err := NewLocal(failingSource{}).List(context.Background(), suite.params())
+	suite.Require().True(IsUpstreamUnavailable(err), err)
+
+	// Like the service, the failure is retryable
+	var appErr *temporal.ApplicationError
+	suite.Require().ErrorAs(err, &appErr)
+	suite.Require().False(appErr.NonRetryable())
+}
+
+func (suite *LocalSuite) TestStartList() {
+	handle, err := suite.client.StartList(context.Background(), suite.params())
+	suite.Require().NoError(err)
+
+	res, done, err := suite.client.PollList(context.Background(), handle)
+	suite.Require().NoError(err)
+	suite.Require().True(done)
+	suite.Require().Len(res.Data, 2)
+
+	// The results are released once returned
+	_, err = suite.client.GetListResult(context.Background(), handle)
+	suite.Require().ErrorIs(err, ErrUnknownListHandle)
+	suite.Require().Empty(suite.client.(*localClient).results)
+
+	_, err = suite.client.GetListResult(context.Background(), ListHandle{WorkflowID: "unknown"})
+	suite.Require().ErrorIs(err, ErrUnknownListHandle)
+}
@@ -0,0 +1,118 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cryptellation/sma/api"
+	enumspb "go.temporal.io/api/enums/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+)
+
+// WorkflowOption overrides the options used to start a workflow from the client.
+type WorkflowOption func(o *temporalclient.StartWorkflowOptions)
+
+// WithWorkflowID overrides the workflow ID derived from the request.
+func WithWorkflowID(id string) WorkflowOption {
+	return func(o *temporalclient.StartWorkflowOptions) {
+		o.ID = id
+	}
+}
+
+// WithWorkflowIDReusePolicy overrides the policy applied when a closed
+// workflow with the same ID already exists.
+func WithWorkflowIDReusePolicy(policy enumspb.WorkflowIdReusePolicy) WorkflowOption {
+	return func(o *temporalclient.StartWorkflowOptions) {
+		o.WorkflowIDReusePolicy = policy
+	}
+}
+
+// WithExecutionTimeout sets the timeout of the whole workflow execution,
+// including retries and continue as new.
+func WithExecutionTimeout(timeout time.Duration) WorkflowOption {
+	return func(o *temporalclient.StartWorkflowOptions) {
+		o.WorkflowExecutionTimeout = timeout
+	}
+}
+
+// WithRunTimeout sets the timeout of a single workflow run.
+func WithRunTimeout(timeout time.Duration) WorkflowOption {
+	return func(o *temporalclient.StartWorkflowOptions) {
+		o.WorkflowRunTimeout = timeout
+	}
+}
+
+// WithTaskTimeout sets the timeout of a single workflow task.
+func WithTaskTimeout(timeout time.Duration) WorkflowOption {
+	return func(o *temporalclient.StartWorkflowOptions) {
+		o.WorkflowTaskTimeout = timeout
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the workflow.
+func WithRetryPolicy(policy *temporal.RetryPolicy) WorkflowOption {
+	return func(o *temporalclient.StartWorkflowOptions) {
+		o.RetryPolicy = policy
+	}
+}
+
+// WithSearchAttributes sets the typed search attributes of the workflow.
+func WithSearchAttributes(attributes temporal.SearchAttributes) WorkflowOption {
+	return func(o *temporalclient.StartWorkflowOptions) {
+		o.TypedSearchAttributes = attributes
+	}
+}
+
+// WithMemo sets the memo of the workflow.
+func WithMemo(memo map[string]interface{}) WorkflowOption {
+	return func(o *temporalclient.StartWorkflowOptions) {
+		o.Memo = memo
+	}
+}
+
+// ListWorkflowID returns the workflow ID derived from the list parameters.
+// Identical requests get the same ID so that concurrent calls attach to the
+// same execution instead of computing the same points several times. The
+// client prefixes it with its task queue when starting the workflow.
+func ListWorkflowID(params api.ListWorkflowParams) string {
+	format := params.Format
+	if format == "" {
+		format = api.ResultFormatPoints
+	}
+
+	return strings.Join([]string{
+		api.ListWorkflowName,
+		params.Exchange,
+		params.Pair,
+		params.Period.String(),
+		fmt.Sprint(params.PeriodNumber),
+		params.PriceType.String(),
+		params.Start.UTC().Format(time.RFC3339),
+		params.End.UTC().Format(time.RFC3339),
+		string(format),
+	}, "/")
+}
+
+// InvalidateWorkflowID returns the workflow ID derived from the invalidate
+// parameters, so that concurrent identical invalidations are run once. The
+// client prefixes it with its task queue when starting the workflow.
+func InvalidateWorkflowID(params api.InvalidateWorkflowParams) string {
+	return strings.Join([]string{
+		api.InvalidateWorkflowName,
+		params.Exchange,
+		params.Pair,
+		params.Period.String(),
+		params.Start.UTC().Format(time.RFC3339),
+		params.End.UTC().Format(time.RFC3339),
+	}, "/")
+}
+
+// ExportWorkflowID returns the workflow ID derived from the export parameters.
+// It only depends on the file name, so that two exports never write the same
+// file at the same time: an export started while another one of the same file
+// is running fails with ErrExportInProgress.
+func ExportWorkflowID(params api.ExportWorkflowParams) string {
+	return api.ExportWorkflowName + "/" + params.FileName
+}
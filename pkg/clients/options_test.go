@@ -0,0 +1,125 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+)
+
+func TestOptionsSuite(t *testing.T) {
+	suite.Run(t, new(OptionsSuite))
+}
+
+type OptionsSuite struct {
+	suite.Suite
+}
+
+func (suite *OptionsSuite) listParams() api.ListWorkflowParams {
+	return api.ListWorkflowParams{
+		Exchange:     "binance",
+		Pair:         "BTC-USDT",
+		Period:       period.M1,
+		Start:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+		PeriodNumber: 20,
+		PriceType:    candlestick.PriceTypeIsClose,
+	}
+}
+
+func (suite *OptionsSuite) TestListWorkflowID() {
+	params := suite.listParams()
+
+	// Same request in another location and with explicit default format
+	same := params
+	same.Start = params.Start.In(time.FixedZone("UTC+2", 2*60*60))
+	same.Format = api.ResultFormatPoints
+	suite.Require().Equal(ListWorkflowID(params), ListWorkflowID(same))
+	suite.Require().Equal(
+		"ListWorkflow/binance/BTC-USDT/M1/20/close/2024-01-01T00:00:00Z/2024-01-01T01:00:00Z/points",
+		ListWorkflowID(params))
+
+	// Different request
+	other := params
+	other.PeriodNumber = 50
+	suite.Require().NotEqual(ListWorkflowID(params), ListWorkflowID(other))
+}
+
+func (suite *OptionsSuite) TestApplyWorkflowOptions() {
+	retry := &temporal.RetryPolicy{MaximumAttempts: 3}
+	memo := map[string]interface{}{"origin": "test"}
+
+	c := New(nil).(*client)
+	options := c.workflowOptions(ListWorkflowID(suite.listParams()),
+		WithWorkflowID("custom-id"),
+		WithWorkflowIDReusePolicy(enumspb.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE),
+		WithExecutionTimeout(time.Minute),
+		WithRunTimeout(30*time.Second),
+		WithTaskTimeout(10*time.Second),
+		WithRetryPolicy(retry),
+		WithMemo(memo),
+	)
+
+	suite.Require().Equal("custom-id", options.ID)
+	suite.Require().Equal(api.WorkerTaskQueueName, options.TaskQueue)
+	suite.Require().Equal(enumspb.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE, options.WorkflowIDReusePolicy)
+	suite.Require().Equal(enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING, options.WorkflowIDConflictPolicy)
+	suite.Require().Equal(time.Minute, options.WorkflowExecutionTimeout)
+	suite.Require().Equal(30*time.Second, options.WorkflowRunTimeout)
+	suite.Require().Equal(10*time.Second, options.WorkflowTaskTimeout)
+	suite.Require().Equal(retry, options.RetryPolicy)
+	suite.Require().Equal(memo, options.Memo)
+}
+
+func (suite *OptionsSuite) TestClientOptions() {
+	retry := &temporal.RetryPolicy{MaximumAttempts: 5}
+
+	// Defaults
+	c := New(nil).(*client)
+	options := c.workflowOptions("")
+	suite.Require().Equal(api.WorkerTaskQueueName, options.TaskQueue)
+	suite.Require().Empty(options.ID)
+	suite.Require().Zero(options.WorkflowExecutionTimeout)
+
+	// Client options
+	c = New(nil,
+		WithNamespace("staging"),
+		WithDefaultExecutionTimeout(time.Minute),
+		WithDefaultRunTimeout(30*time.Second),
+		WithDefaultRetryPolicy(retry),
+	).(*client)
+	options = c.workflowOptions("id")
+	suite.Require().Equal("CryptellationSmaTaskQueue-staging", options.TaskQueue)
+	suite.Require().Equal("CryptellationSmaTaskQueue-staging/id", options.ID)
+	suite.Require().Equal(time.Minute, options.WorkflowExecutionTimeout)
+	suite.Require().Equal(30*time.Second, options.WorkflowRunTimeout)
+	suite.Require().Equal(retry, options.RetryPolicy)
+
+	// Call options take precedence over client options
+	options = c.workflowOptions("id", WithExecutionTimeout(time.Hour))
+	suite.Require().Equal(time.Hour, options.WorkflowExecutionTimeout)
+
+	// Explicit task queue
+	c = New(nil, WithTaskQueue("custom")).(*client)
+	suite.Require().Equal("custom", c.workflowOptions("").TaskQueue)
+}
+
+func (suite *OptionsSuite) TestWorkflowIDPerTaskQueue() {
+	params := suite.listParams()
+
+	// WHEN starting the same request from two deployments
+	staging := New(nil, WithNamespace("staging")).(*client).workflowOptions(ListWorkflowID(params))
+	prod := New(nil, WithNamespace("prod")).(*client).workflowOptions(ListWorkflowID(params))
+
+	// THEN the executions have distinct IDs
+	suite.Require().Equal("CryptellationSmaTaskQueue-staging/"+ListWorkflowID(params), staging.ID)
+	suite.Require().NotEqual(staging.ID, prod.ID)
+}
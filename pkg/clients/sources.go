@@ -0,0 +1,237 @@
+package clients
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	candlesticksapi "github.com/cryptellation/candlesticks/api"
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	candlesticksclients "github.com/cryptellation/candlesticks/pkg/clients"
+	"github.com/cryptellation/candlesticks/pkg/period"
+)
+
+// CandlestickSource provides the candlesticks used by the local client to
+// compute SMA points.
+type CandlestickSource interface {
+	// ListCandlesticks returns the candlesticks between start and end (included).
+	ListCandlesticks(
+		ctx context.Context,
+		exchange, pair string,
+		per period.Symbol,
+		start, end time.Time,
+	) ([]candlestick.Candlestick, error)
+}
+
+// candlesticksClientSource gets the candlesticks from the candlesticks service.
+type candlesticksClientSource struct {
+	client candlesticksclients.Client
+}
+
+// NewCandlesticksClientSource creates a source getting the candlesticks from
+// the candlesticks service.
+func NewCandlesticksClientSource(cl candlesticksclients.Client) CandlestickSource {
+	return candlesticksClientSource{client: cl}
+}
+
+// ListCandlesticks returns the candlesticks between start and end (included).
+func (s candlesticksClientSource) ListCandlesticks(
+	ctx context.Context,
+	exchange, pair string,
+	per period.Symbol,
+	start, end time.Time,
+) ([]candlestick.Candlestick, error) {
+	res, err := s.client.ListCandlesticks(ctx, candlesticksapi.ListCandlesticksWorkflowParams{
+		Exchange: exchange,
+		Pair:     pair,
+		Period:   per,
+		Start:    &start,
+		End:      &end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.List, nil
+}
+
+// ErrUnknownCandlesticksFileType is returned when the type of a candlesticks
+// file cannot be deduced from its extension.
+var ErrUnknownCandlesticksFileType = errors.New("unknown candlesticks file type")
+
+// MemorySource is a source holding candlesticks in memory.
+type MemorySource struct {
+	mutex sync.RWMutex
+	lists map[candlestick.ListMetadata]*candlestick.List
+}
+
+// NewMemorySource creates a source holding the given candlesticks lists.
+func NewMemorySource(lists ...*candlestick.List) (*MemorySource, error) {
+	s := &MemorySource{
+		lists: make(map[candlestick.ListMetadata]*candlestick.List),
+	}
+
+	for _, l := range lists {
+		if err := s.Add(l); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Add adds the candlesticks of the list to the source.
+func (s *MemorySource) Add(l *candlestick.List) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, ok := s.lists[l.Metadata]
+	if !ok {
+		existing = candlestick.NewListWithMetadata(l.Metadata)
+		s.lists[l.Metadata] = existing
+	}
+
+	return existing.Merge(l, nil)
+}
+
+// ListCandlesticks returns the candlesticks between start and end (included).
+func (s *MemorySource) ListCandlesticks(
+	_ context.Context,
+	exchange, pair string,
+	per period.Symbol,
+	start, end time.Time,
+) ([]candlestick.Candlestick, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	l, ok := s.lists[candlestick.ListMetadata{
+		Exchange: exchange,
+		Pair:     pair,
+		Period:   per,
+	}]
+	if !ok {
+		return nil, nil
+	}
+
+	return l.Extract(start, end, 0).ToArray(), nil
+}
+
+// NewCSVSource creates a source holding the candlesticks of the CSV file
+// for the given exchange, pair and period. See ReadCSV for the file format.
+func NewCSVSource(path, exchange, pair string, per period.Symbol) (*MemorySource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	l, err := ReadCSV(f, exchange, pair, per)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", path, err)
+	}
+
+	return NewMemorySource(l)
+}
+
+// ReadCandlesticksFile reads the candlesticks of a CSV (see ReadCSV) or JSON
+// (see ReadJSON) file, depending on its extension.
+func ReadCandlesticksFile(path, exchange, pair string, per period.Symbol) (*candlestick.List, error) {
+	var read func(io.Reader, string, string, period.Symbol) (*candlestick.List, error)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		read = ReadCSV
+	case ".json":
+		read = ReadJSON
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCandlesticksFileType, ext)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	l, err := read(f, exchange, pair, per)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", path, err)
+	}
+
+	return l, nil
+}
+
+// ReadJSON reads candlesticks from a JSON array of objects with the time
+// (RFC3339), open, high, low, close and volume fields.
+func ReadJSON(r io.Reader, exchange, pair string, per period.Symbol) (*candlestick.List, error) {
+	var candlesticks []candlestick.Candlestick
+	if err := json.NewDecoder(r).Decode(&candlesticks); err != nil {
+		return nil, err
+	}
+
+	l := candlestick.NewList(exchange, pair, per)
+	for _, cs := range candlesticks {
+		if err := l.Set(cs); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// ReadCSV reads candlesticks from a CSV with a header line and the columns
+// time (RFC3339), open, high, low, close and volume.
+func ReadCSV(r io.Reader, exchange, pair string, per period.Symbol) (*candlestick.List, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 6
+
+	// Skip the header
+	if _, err := reader.Read(); err != nil {
+		return nil, err
+	}
+
+	l := candlestick.NewList(exchange, pair, per)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		cs, err := candlestickFromCSVRecord(record)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := l.Set(cs); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+func candlestickFromCSVRecord(record []string) (cs candlestick.Candlestick, err error) {
+	cs.Time, err = time.Parse(time.RFC3339, record[0])
+	if err != nil {
+		return candlestick.Candlestick{}, err
+	}
+
+	values := []*float64{&cs.Open, &cs.High, &cs.Low, &cs.Close, &cs.Volume}
+	for i, v := range values {
+		*v, err = strconv.ParseFloat(record[i+1], 64)
+		if err != nil {
+			return candlestick.Candlestick{}, err
+		}
+	}
+
+	return cs, nil
+}
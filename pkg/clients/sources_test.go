@@ -0,0 +1,63 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/stretchr/testify/suite"
+)
+
+const sourcesTestJSON = `[
+	{"time":"1970-01-01T00:00:00Z","close":1000},
+	{"time":"1970-01-01T00:01:00Z","close":1500},
+	{"time":"1970-01-01T00:02:00Z","close":1250},
+	{"time":"1970-01-01T00:03:00Z","close":1300}
+]`
+
+func TestSourcesSuite(t *testing.T) {
+	suite.Run(t, new(SourcesSuite))
+}
+
+type SourcesSuite struct {
+	suite.Suite
+}
+
+func (suite *SourcesSuite) TestReadJSONMatchesCSV() {
+	fromJSON, err := ReadJSON(strings.NewReader(sourcesTestJSON), "exchange", "ETH-USDC", period.M1)
+	suite.Require().NoError(err)
+	fromCSV, err := ReadCSV(strings.NewReader(localTestCSV), "exchange", "ETH-USDC", period.M1)
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(fromCSV.ToArray(), fromJSON.ToArray())
+}
+
+func (suite *SourcesSuite) TestReadCandlesticksFile() {
+	dir := suite.T().TempDir()
+	files := map[string]string{
+		"candlesticks.csv":  localTestCSV,
+		"candlesticks.JSON": sourcesTestJSON,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		suite.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+
+		l, err := ReadCandlesticksFile(path, "exchange", "ETH-USDC", period.M1)
+		suite.Require().NoError(err, name)
+		cs, ok := l.Last()
+		suite.Require().True(ok, name)
+		suite.Require().Equal(time.Unix(180, 0).UTC(), cs.Time.UTC(), name)
+		suite.Require().Equal(1300.0, cs.Close, name)
+	}
+
+	// Unknown extension
+	_, err := ReadCandlesticksFile(filepath.Join(dir, "candlesticks.txt"), "exchange", "ETH-USDC", period.M1)
+	suite.Require().ErrorIs(err, ErrUnknownCandlesticksFileType)
+}
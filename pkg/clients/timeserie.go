@@ -0,0 +1,25 @@
+package clients
+
+import (
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/timeseries"
+)
+
+// ResultsToTimeSerie converts the results of the List workflow into a timeserie,
+// whatever the format they have been requested in. Missing points of compact
+// results are not set in the timeserie.
+func ResultsToTimeSerie(res api.ListWorkflowResults) (*timeseries.TimeSerie[float64], error) {
+	data := res.Data
+	if res.Compact != nil {
+		if err := res.Compact.Period.Validate(); err != nil {
+			return nil, err
+		}
+		data = res.Compact.Points()
+	}
+
+	ts := timeseries.New[float64]()
+	for _, d := range data {
+		ts.Set(d.Time, d.Value)
+	}
+	return ts, nil
+}
@@ -0,0 +1,44 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTimeSerieSuite(t *testing.T) {
+	suite.Run(t, new(TimeSerieSuite))
+}
+
+type TimeSerieSuite struct {
+	suite.Suite
+}
+
+func (suite *TimeSerieSuite) TestResultsToTimeSerie() {
+	data := []api.SMADataPoint{
+		{Time: time.Unix(0, 0), Value: 1},
+		{Time: time.Unix(120, 0), Value: 3},
+	}
+
+	cases := []api.ListWorkflowResults{
+		{Data: data},
+		{Compact: api.NewCompactData(time.Unix(0, 0), time.Unix(180, 0), period.M1, data)},
+	}
+
+	for i, c := range cases {
+		ts, err := ResultsToTimeSerie(c)
+		suite.Require().NoError(err, i)
+		suite.Require().Equal(2, ts.Len(), i)
+		for _, d := range data {
+			v, exists := ts.Get(d.Time)
+			suite.Require().True(exists, i)
+			suite.Require().Equal(d.Value, v, i)
+		}
+	}
+}
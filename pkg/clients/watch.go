@@ -0,0 +1,145 @@
+package clients
+
+import (
+	"context"
+	"time"
+
+	"github.com/cryptellation/sma/api"
+	"go.temporal.io/sdk/temporal"
+)
+
+const (
+	// DefaultWatchRetryInitialInterval is the default interval before retrying
+	// to get the point of a closed period for the first time.
+	DefaultWatchRetryInitialInterval = time.Second
+	// DefaultWatchRetryMaxInterval is the default maximum interval between two
+	// retries to get the point of a closed period.
+	DefaultWatchRetryMaxInterval = time.Minute
+)
+
+// WatchOption is an option of Watch.
+type WatchOption func(w *watcher)
+
+// WithWatchDelay sets the delay after the end of a period before getting its
+// point.
+func WithWatchDelay(delay time.Duration) WatchOption {
+	return func(w *watcher) {
+		w.delay = delay
+	}
+}
+
+// WithWatchRetryIntervals sets the interval before the first retry to get the
+// point of a closed period, doubled on each retry up to the maximum interval.
+func WithWatchRetryIntervals(initial, maximum time.Duration) WatchOption {
+	return func(w *watcher) {
+		w.retryInitialInterval = initial
+		w.retryMaxInterval = maximum
+	}
+}
+
+// WithWatchClock sets the functions used to get the current time and to wait,
+// instead of time.Now and time.After.
+func WithWatchClock(now func() time.Time, after func(d time.Duration) <-chan time.Time) WatchOption {
+	return func(w *watcher) {
+		w.now = now
+		w.after = after
+	}
+}
+
+type watcher struct {
+	delay                time.Duration
+	retryInitialInterval time.Duration
+	retryMaxInterval     time.Duration
+	now                  func() time.Time
+	after                func(d time.Duration) <-chan time.Time
+}
+
+// Watch calls fn with the SMA point of each period once it is closed, starting
+// with the last closed one, until the context is done or fn returns an error.
+// The Start and End of the parameters are ignored.
+//
+// The point of a just closed period can be missing (e.g. as its candlestick is
+// not available yet): the request is then retried with an exponential backoff
+// until the point exists, including the points of the periods closed in the
+// meantime. Only invalid arguments stop the watch with an error.
+func Watch(
+	ctx context.Context,
+	cl Client,
+	params api.ListWorkflowParams,
+	fn func(p api.SMADataPoint) error,
+	opts ...WatchOption,
+) error {
+	w := watcher{
+		retryInitialInterval: DefaultWatchRetryInitialInterval,
+		retryMaxInterval:     DefaultWatchRetryMaxInterval,
+		now:                  time.Now,
+		after:                time.After,
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+
+	// Validate parameters
+	params.Start, params.End = w.now(), w.now()
+	if err := params.Validate(); err != nil {
+		return temporal.NewNonRetryableApplicationError(err.Error(), api.ErrTypeInvalidArgument, err)
+	}
+	interval := params.Period.Duration()
+
+	var lastSent time.Time
+	retryInterval := w.retryInitialInterval
+	for {
+		// Wait for the end of the current period by default
+		lastClosed := params.Period.RoundTime(w.now()).Add(-interval)
+		wait := lastClosed.Add(2 * interval).Add(w.delay).Sub(w.now())
+
+		if lastClosed.After(lastSent) {
+			// Get the points from the first one not sent up to the last closed
+			// one, by chunks if the watch has been late for long
+			params.Start, params.End = lastClosed, lastClosed
+			if !lastSent.IsZero() {
+				params.Start = lastSent.Add(interval)
+			}
+
+			var fnErr error
+			err := ListChunks(ctx, cl, params, DefaultListChunkSize,
+				func(_ api.ListWorkflowParams, res api.ListWorkflowResults) error {
+					for _, p := range res.Data {
+						if !p.Time.After(lastSent) {
+							continue
+						}
+						if fnErr = fn(p); fnErr != nil {
+							return fnErr
+						}
+						lastSent = p.Time
+					}
+					return nil
+				})
+			switch {
+			case fnErr != nil:
+				return fnErr
+			case ctx.Err() != nil:
+				return nil
+			case IsInvalidArgument(err):
+				return err
+			}
+
+			// Retry if the point of the last closed period is not there yet
+			if err != nil || lastSent.Before(lastClosed) {
+				wait = retryInterval
+				retryInterval = min(2*retryInterval, w.retryMaxInterval)
+			} else {
+				retryInterval = w.retryInitialInterval
+			}
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.after(wait):
+		}
+	}
+}
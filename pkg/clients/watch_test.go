@@ -0,0 +1,152 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
+)
+
+// scriptedClient answers the list requests with the given functions, in order.
+type scriptedClient struct {
+	Client
+	requests  []api.ListWorkflowParams
+	responses []func(params api.ListWorkflowParams) (api.ListWorkflowResults, error)
+}
+
+func (c *scriptedClient) List(
+	_ context.Context,
+	params api.ListWorkflowParams,
+	_ ...WorkflowOption,
+) (api.ListWorkflowResults, error) {
+	c.requests = append(c.requests, params)
+	i := min(len(c.requests), len(c.responses)) - 1
+	return c.responses[i](params)
+}
+
+// pointsOf returns a point for each period of the requested range.
+func pointsOf(params api.ListWorkflowParams) (api.ListWorkflowResults, error) {
+	var res api.ListWorkflowResults
+	for t := params.Start; !t.After(params.End); t = t.Add(params.Period.Duration()) {
+		res.Data = append(res.Data, api.SMADataPoint{Time: t, Value: float64(t.Unix())})
+	}
+	return res, nil
+}
+
+func TestWatchSuite(t *testing.T) {
+	suite.Run(t, new(WatchSuite))
+}
+
+type WatchSuite struct {
+	suite.Suite
+	now   time.Time
+	waits []time.Duration
+}
+
+func (suite *WatchSuite) SetupTest() {
+	// The period at 00:03 is the last closed one
+	suite.now = time.Unix(250, 0)
+	suite.waits = nil
+}
+
+func (suite *WatchSuite) params() api.ListWorkflowParams {
+	return api.ListWorkflowParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDC",
+		Period:       period.M1,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+	}
+}
+
+// watch watches with the client until count points are received.
+func (suite *WatchSuite) watch(cl Client, count int) ([]int64, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var times []int64
+	err := Watch(ctx, cl, suite.params(), func(p api.SMADataPoint) error {
+		times = append(times, p.Time.Unix())
+		if len(times) == count {
+			cancel()
+		}
+		return nil
+	}, WithWatchClock(func() time.Time {
+		return suite.now
+	}, func(d time.Duration) <-chan time.Time {
+		// Jump to the requested time
+		suite.waits = append(suite.waits, d)
+		suite.now = suite.now.Add(d)
+		ch := make(chan time.Time, 1)
+		ch <- suite.now
+		return ch
+	}), WithWatchRetryIntervals(10*time.Second, 40*time.Second))
+
+	return times, err
+}
+
+func (suite *WatchSuite) TestWatch() {
+	cl := &scriptedClient{responses: []func(api.ListWorkflowParams) (api.ListWorkflowResults, error){pointsOf}}
+
+	times, err := suite.watch(cl, 3)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]int64{180, 240, 300}, times)
+	suite.Require().Len(cl.requests, 3)
+	suite.Require().Equal([]time.Duration{50 * time.Second, 60 * time.Second}, suite.waits)
+}
+
+func (suite *WatchSuite) TestWatchRetry() {
+	notFound := func(api.ListWorkflowParams) (api.ListWorkflowResults, error) {
+		err := errors.New("no candlesticks")
+		return api.ListWorkflowResults{}, temporal.NewNonRetryableApplicationError(err.Error(), api.ErrTypeNotFound, err)
+	}
+	empty := func(api.ListWorkflowParams) (api.ListWorkflowResults, error) {
+		return api.ListWorkflowResults{}, nil
+	}
+
+	// GIVEN a client that does not have the point of the last closed period
+	// yet, until the next period is closed too
+	cl := &scriptedClient{responses: []func(api.ListWorkflowParams) (api.ListWorkflowResults, error){
+		pointsOf, notFound, empty, notFound, notFound, pointsOf,
+	}}
+
+	// WHEN watching
+	times, err := suite.watch(cl, 3)
+
+	// THEN the requests are retried with backoff, without skipping a period
+	suite.Require().NoError(err)
+	suite.Require().Equal([]int64{180, 240, 300}, times)
+	suite.Require().Equal([]time.Duration{
+		50 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, 40 * time.Second,
+	}, suite.waits)
+	last := cl.requests[len(cl.requests)-1]
+	suite.Require().Equal(int64(240), last.Start.Unix())
+	suite.Require().Equal(int64(300), last.End.Unix())
+}
+
+func (suite *WatchSuite) TestWatchInvalidArgument() {
+	cl := &scriptedClient{}
+	params := suite.params()
+	params.Pair = "ETHUSDC"
+
+	err := Watch(context.Background(), cl, params, func(api.SMADataPoint) error { return nil })
+	suite.Require().True(IsInvalidArgument(err), err)
+	suite.Require().Empty(cl.requests)
+}
+
+func (suite *WatchSuite) TestWatchCallbackError() {
+	cl := &scriptedClient{responses: []func(api.ListWorkflowParams) (api.ListWorkflowResults, error){pointsOf}}
+	expected := errors.New("error")
+
+	err := Watch(context.Background(), cl, suite.params(), func(api.SMADataPoint) error { return expected })
+	suite.Require().ErrorIs(err, expected)
+}
@@ -1,14 +1,75 @@
 package clients
 
+import (
+	"github.com/cryptellation/sma/api"
+	"go.temporal.io/sdk/workflow"
+)
+
 // WfClient is a client for the cryptellation sma service from a workflow perspective.
 type WfClient interface {
+	// Invalidate invalidates SMA points from Cryptellation service.
+	Invalidate(
+		ctx workflow.Context,
+		params api.InvalidateWorkflowParams,
+		childWorkflowOptions *workflow.ChildWorkflowOptions,
+	) (result api.InvalidateWorkflowResults, err error)
+}
+
+type wfClient struct {
+	taskQueue string
 }
 
-type wfClient struct{}
+// WfClientOption is an option of the workflow client.
+type WfClientOption func(c *wfClient)
+
+// WithWfNamespace sets the task queue of the child workflows to the one of the
+// SMA deployment running in the given namespace (see api.TaskQueueName).
+func WithWfNamespace(namespace string) WfClientOption {
+	return func(c *wfClient) {
+		c.taskQueue = api.TaskQueueName(namespace)
+	}
+}
 
 // NewWfClient creates a new workflow client.
 // This client is used to call workflows from within other workflows.
 // It is not used to call workflows from outside the workflow environment.
-func NewWfClient() WfClient {
-	return wfClient{}
+func NewWfClient(opts ...WfClientOption) WfClient {
+	c := wfClient{
+		taskQueue: api.WorkerTaskQueueName,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Invalidate invalidates SMA points from Cryptellation service.
+func (c wfClient) Invalidate(
+	ctx workflow.Context,
+	params api.InvalidateWorkflowParams,
+	childWorkflowOptions *workflow.ChildWorkflowOptions,
+) (result api.InvalidateWorkflowResults, err error) {
+	// Set default options
+	ctx = c.setDefaultChildWorkflowOptions(ctx, childWorkflowOptions)
+
+	// Invalidate SMA points
+	err = workflow.ExecuteChildWorkflow(ctx, api.InvalidateWorkflowName, params).Get(ctx, &result)
+	return result, err
+}
+
+func (c wfClient) setDefaultChildWorkflowOptions(
+	ctx workflow.Context,
+	childWorkflowOptions *workflow.ChildWorkflowOptions,
+) workflow.Context {
+	// Create default child workflow options
+	if childWorkflowOptions == nil {
+		childWorkflowOptions = &workflow.ChildWorkflowOptions{}
+	}
+
+	// Set default options
+	if childWorkflowOptions.TaskQueue == "" {
+		childWorkflowOptions.TaskQueue = c.taskQueue
+	}
+
+	return workflow.WithChildOptions(ctx, *childWorkflowOptions)
 }
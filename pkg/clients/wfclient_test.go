@@ -0,0 +1,68 @@
+//go:build unit
+// +build unit
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestWfClientSuite(t *testing.T) {
+	suite.Run(t, new(WfClientSuite))
+}
+
+type WfClientSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+}
+
+func (suite *WfClientSuite) TestInvalidateTaskQueue() {
+	cases := []struct {
+		Name     string
+		Options  []WfClientOption
+		Expected string
+	}{
+		{
+			Name:     "default",
+			Expected: api.WorkerTaskQueueName,
+		},
+		{
+			Name:     "empty namespace",
+			Options:  []WfClientOption{WithWfNamespace("")},
+			Expected: api.WorkerTaskQueueName,
+		},
+		{
+			Name:     "namespace",
+			Options:  []WfClientOption{WithWfNamespace("staging")},
+			Expected: "CryptellationSmaTaskQueue-staging",
+		},
+	}
+
+	for _, c := range cases {
+		env := suite.NewTestWorkflowEnvironment()
+
+		// GIVEN an invalidate workflow recording its task queue
+		var taskQueue string
+		env.RegisterWorkflowWithOptions(func(ctx workflow.Context, _ api.InvalidateWorkflowParams) (
+			api.InvalidateWorkflowResults, error,
+		) {
+			taskQueue = workflow.GetInfo(ctx).TaskQueueName
+			return api.InvalidateWorkflowResults{}, nil
+		}, workflow.RegisterOptions{Name: api.InvalidateWorkflowName})
+
+		// WHEN invalidating from a workflow with the client
+		env.ExecuteWorkflow(func(ctx workflow.Context) error {
+			_, err := NewWfClient(c.Options...).Invalidate(ctx, api.InvalidateWorkflowParams{}, nil)
+			return err
+		})
+
+		// THEN the child workflow runs on the task queue of the namespace
+		suite.Require().NoError(env.GetWorkflowError(), c.Name)
+		suite.Require().Equal(c.Expected, taskQueue, c.Name)
+	}
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// columns are the names of the exported columns.
+var columns = []string{"exchange", "pair", "period", "length", "price_type", "time", "value"}
+
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+// Write writes a row.
+func (c *csvWriter) Write(r Row) error {
+	if !c.header {
+		c.header = true
+		if err := c.w.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	return c.w.Write([]string{
+		r.Exchange,
+		r.Pair,
+		r.Period.String(),
+		strconv.Itoa(r.PeriodNumber),
+		r.PriceType.String(),
+		r.Time.UTC().Format(time.RFC3339),
+		strconv.FormatFloat(r.Value, 'f', -1, 64),
+	})
+}
+
+// Close flushes the remaining rows.
+func (c *csvWriter) Close() error {
+	if !c.header {
+		c.header = true
+		if err := c.w.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,44 @@
+// Package export writes SMA series to files.
+package export
+
+import (
+	"io"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+)
+
+// Row is an exported SMA point.
+type Row struct {
+	Exchange     string
+	Pair         string
+	Period       period.Symbol
+	PeriodNumber int
+	PriceType    candlestick.PriceType
+	Time         time.Time
+	Value        float64
+}
+
+// Writer writes rows to a file. Rows are written as they come, so that series
+// too large to be held in memory can be exported.
+type Writer interface {
+	// Write writes a row.
+	Write(r Row) error
+	// Close flushes the remaining rows and completes the file. It does not
+	// close the underlying writer.
+	Close() error
+}
+
+// NewWriter creates a writer of the given format.
+func NewWriter(w io.Writer, format api.ExportFormat) (Writer, error) {
+	switch format {
+	case api.ExportFormatCSV:
+		return newCSVWriter(w), nil
+	case api.ExportFormatParquet:
+		return newParquetWriter(w, DefaultRowGroupSize), nil
+	default:
+		return nil, format.Validate()
+	}
+}
@@ -0,0 +1,113 @@
+//go:build unit
+// +build unit
+
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
+
+type ExportSuite struct {
+	suite.Suite
+}
+
+func (suite *ExportSuite) rows(n int) []Row {
+	rows := make([]Row, n)
+	for i := range rows {
+		rows[i] = Row{
+			Exchange:     "binance",
+			Pair:         "ETH-USDT",
+			Period:       period.M1,
+			PeriodNumber: 20,
+			PriceType:    candlestick.PriceTypeIsClose,
+			Time:         time.Unix(int64(i*60), 0),
+			Value:        float64(i) + 0.5,
+		}
+	}
+	return rows
+}
+
+func (suite *ExportSuite) TestCSV() {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, api.ExportFormatCSV)
+	suite.Require().NoError(err)
+	for _, r := range suite.rows(2) {
+		suite.Require().NoError(w.Write(r))
+	}
+	suite.Require().NoError(w.Close())
+
+	suite.Require().Equal(
+		"exchange,pair,period,length,price_type,time,value\n"+
+			"binance,ETH-USDT,M1,20,close,1970-01-01T00:00:00Z,0.5\n"+
+			"binance,ETH-USDT,M1,20,close,1970-01-01T00:01:00Z,1.5\n",
+		buf.String())
+}
+
+func (suite *ExportSuite) TestUnknownFormat() {
+	_, err := NewWriter(&bytes.Buffer{}, "xlsx")
+	suite.Require().ErrorIs(err, api.ErrUnknownExportFormat)
+}
+
+func (suite *ExportSuite) TestParquet() {
+	rows := suite.rows(5)
+
+	var buf bytes.Buffer
+	w := newParquetWriter(&buf, 2)
+	for _, r := range rows {
+		suite.Require().NoError(w.Write(r))
+	}
+	suite.Require().NoError(w.Close())
+
+	// Open the file and check its schema and row groups (2, 2 and 1 rows)
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(len(rows)), f.NumRows())
+	suite.Require().Len(f.RowGroups(), 3)
+
+	fields := f.Schema().Fields()
+	suite.Require().Len(fields, len(columns))
+	for i, name := range columns {
+		suite.Require().Equal(name, fields[i].Name())
+	}
+	timeColumn, ok := f.Schema().Lookup("time")
+	suite.Require().True(ok)
+	suite.Require().NotNil(timeColumn.Node.Type().LogicalType().Timestamp)
+
+	// Read back the rows
+	type row struct {
+		Exchange  string    `parquet:"exchange"`
+		Pair      string    `parquet:"pair"`
+		Period    string    `parquet:"period"`
+		Length    int32     `parquet:"length"`
+		PriceType string    `parquet:"price_type"`
+		Time      time.Time `parquet:"time,timestamp(millisecond)"`
+		Value     float64   `parquet:"value"`
+	}
+	read, err := parquet.Read[row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	suite.Require().NoError(err)
+	suite.Require().Len(read, len(rows))
+	for i, r := range rows {
+		read[i].Time = read[i].Time.UTC()
+		suite.Require().Equal(row{
+			Exchange:  r.Exchange,
+			Pair:      r.Pair,
+			Period:    r.Period.String(),
+			Length:    int32(r.PeriodNumber),
+			PriceType: r.PriceType.String(),
+			Time:      r.Time.UTC(),
+			Value:     r.Value,
+		}, read[i])
+	}
+}
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/cryptellation/sma/api"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+)
+
+// heartbeatInterval is the count of written rows between two heartbeats.
+const heartbeatInterval = 10_000
+
+// ErrFileExists is returned when the file of an export already exists and
+// is not to be overwritten.
+var ErrFileExists = errors.New("export file already exists")
+
+// File is the file created by an export.
+type File struct {
+	// Path is the path of the file.
+	Path string
+	// Format is the format of the file.
+	Format api.ExportFormat
+	// Overwrite replaces the file if it already exists.
+	Overwrite bool
+}
+
+// Write creates the file and writes the rows given by the loop. It records
+// heartbeats when run as an activity and returns the count of written rows.
+//
+// The rows are written to a temporary file of the same directory, that is
+// moved once complete: the file only exists when the export succeeded, and
+// a retried export never leaves a partial file. An existing file is only
+// replaced if Overwrite is set, otherwise a non retryable error wrapping
+// ErrFileExists is returned.
+func (file File) Write(
+	ctx context.Context,
+	loop func(write func(Row) error) error,
+) (count int, err error) {
+	if err := file.checkExists(); err != nil {
+		return 0, err
+	}
+
+	dir, name := filepath.Split(file.Path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, fmt.Errorf("creating export directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, "."+name+".*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("creating export file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+	if err := f.Chmod(0o644); err != nil {
+		return 0, fmt.Errorf("setting export file mode: %w", err)
+	}
+
+	w, err := NewWriter(f, file.Format)
+	if err != nil {
+		return 0, err
+	}
+
+	err = loop(func(r Row) error {
+		count++
+		if count%heartbeatInterval == 0 && activity.IsActivity(ctx) {
+			activity.RecordHeartbeat(ctx, count)
+		}
+		return w.Write(r)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Complete the file, then move it to its final path
+	if err = w.Close(); err != nil {
+		return 0, err
+	}
+	if err = f.Close(); err != nil {
+		return 0, fmt.Errorf("closing export file: %w", err)
+	}
+	if err = file.move(f.Name()); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// checkExists returns an error if the file exists and is not to be overwritten.
+func (file File) checkExists() error {
+	if file.Overwrite {
+		return nil
+	}
+
+	if _, err := os.Lstat(file.Path); err == nil {
+		return file.existsError()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("checking export file: %w", err)
+	}
+	return nil
+}
+
+// move moves the temporary file to the path of the file. Unless the file is
+// to be overwritten, it is linked instead of renamed, so that a file created
+// meanwhile is never replaced.
+func (file File) move(tmp string) error {
+	if file.Overwrite {
+		if err := os.Rename(tmp, file.Path); err != nil {
+			return fmt.Errorf("moving export file: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Link(tmp, file.Path); errors.Is(err, fs.ErrExist) {
+		return file.existsError()
+	} else if err != nil {
+		return fmt.Errorf("moving export file: %w", err)
+	}
+	_ = os.Remove(tmp)
+	return nil
+}
+
+func (file File) existsError() error {
+	err := fmt.Errorf("%w: %s", ErrFileExists, file.Path)
+	return temporal.NewNonRetryableApplicationError(err.Error(), api.ErrTypeAlreadyExists, err)
+}
@@ -0,0 +1,128 @@
+//go:build unit
+// +build unit
+
+package export
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/sma/api"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
+)
+
+func TestFileSuite(t *testing.T) {
+	suite.Run(t, new(FileSuite))
+}
+
+type FileSuite struct {
+	suite.Suite
+	file File
+}
+
+func (suite *FileSuite) SetupTest() {
+	suite.file = File{
+		Path:   filepath.Join(suite.T().TempDir(), "eth", "export.csv"),
+		Format: api.ExportFormatCSV,
+	}
+}
+
+// files returns the names of the files in the directory of the export.
+func (suite *FileSuite) files() []string {
+	entries, err := os.ReadDir(filepath.Dir(suite.file.Path))
+	suite.Require().NoError(err)
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func (suite *FileSuite) TestWrite() {
+	count, err := suite.file.Write(context.Background(), func(write func(Row) error) error {
+		return write(Row{Exchange: "exchange", Time: time.Unix(0, 0), Value: 1})
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+
+	// Only the complete file is left
+	suite.Require().Equal([]string{"export.csv"}, suite.files())
+	info, err := os.Stat(suite.file.Path)
+	suite.Require().NoError(err)
+	suite.Require().Equal(os.FileMode(0o644), info.Mode().Perm())
+}
+
+// writePrevious creates a previous export of the file.
+func (suite *FileSuite) writePrevious() {
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(suite.file.Path), 0o755))
+	suite.Require().NoError(os.WriteFile(suite.file.Path, []byte("previous"), 0o600))
+}
+
+// content returns the content of the file.
+func (suite *FileSuite) content() string {
+	content, err := os.ReadFile(suite.file.Path)
+	suite.Require().NoError(err)
+	return string(content)
+}
+
+func (suite *FileSuite) TestWriteError() {
+	// GIVEN a previous export of the file, that can be overwritten
+	suite.writePrevious()
+	suite.file.Overwrite = true
+
+	// WHEN the export fails after some rows
+	expected := errors.New("error")
+	_, err := suite.file.Write(context.Background(), func(write func(Row) error) error {
+		if err := write(Row{Exchange: "exchange", Time: time.Unix(0, 0), Value: 1}); err != nil {
+			return err
+		}
+		return expected
+	})
+	suite.Require().ErrorIs(err, expected)
+
+	// THEN no partial file is left and the previous one is untouched
+	suite.Require().Equal([]string{"export.csv"}, suite.files())
+	suite.Require().Equal("previous", suite.content())
+}
+
+func (suite *FileSuite) TestWriteExisting() {
+	// GIVEN a previous export of the file
+	suite.writePrevious()
+
+	// WHEN exporting to the same file without overwriting it
+	_, err := suite.file.Write(context.Background(), func(write func(Row) error) error {
+		return write(Row{Exchange: "exchange", Time: time.Unix(0, 0), Value: 1})
+	})
+
+	// THEN the export fails without retries and the previous file is untouched
+	suite.Require().ErrorIs(err, ErrFileExists)
+	var appErr *temporal.ApplicationError
+	suite.Require().ErrorAs(err, &appErr)
+	suite.Require().Equal(api.ErrTypeAlreadyExists, appErr.Type())
+	suite.Require().True(appErr.NonRetryable())
+	suite.Require().Equal([]string{"export.csv"}, suite.files())
+	suite.Require().Equal("previous", suite.content())
+}
+
+func (suite *FileSuite) TestWriteOverwrite() {
+	// GIVEN a previous export of the file, that can be overwritten
+	suite.writePrevious()
+	suite.file.Overwrite = true
+
+	// WHEN exporting to the same file
+	count, err := suite.file.Write(context.Background(), func(write func(Row) error) error {
+		return write(Row{Exchange: "exchange", Time: time.Unix(0, 0), Value: 1})
+	})
+
+	// THEN the file is replaced
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+	suite.Require().Equal([]string{"export.csv"}, suite.files())
+	suite.Require().NotEqual("previous", suite.content())
+}
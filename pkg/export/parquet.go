@@ -0,0 +1,56 @@
+package export
+
+import (
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// DefaultRowGroupSize is the default count of rows held in memory before
+// being written as a Parquet row group.
+const DefaultRowGroupSize = 64 * 1024
+
+// parquetRow is the layout of an exported row in a Parquet file, with the
+// same columns as the CSV export.
+type parquetRow struct {
+	Exchange     string  `parquet:"exchange"`
+	Pair         string  `parquet:"pair"`
+	Period       string  `parquet:"period"`
+	PeriodNumber int32   `parquet:"length"`
+	PriceType    string  `parquet:"price_type"`
+	Time         int64   `parquet:"time,timestamp(millisecond)"`
+	Value        float64 `parquet:"value"`
+}
+
+// parquetWriter writes rows as a Parquet file. Only the rows of the current
+// row group are held in memory.
+type parquetWriter struct {
+	w *parquet.GenericWriter[parquetRow]
+}
+
+func newParquetWriter(w io.Writer, rowGroupSize int) *parquetWriter {
+	return &parquetWriter{
+		w: parquet.NewGenericWriter[parquetRow](w,
+			parquet.MaxRowsPerRowGroup(int64(rowGroupSize)),
+			parquet.CreatedBy("cryptellation sma", "", "")),
+	}
+}
+
+// Write writes a row.
+func (p *parquetWriter) Write(r Row) error {
+	_, err := p.w.Write([]parquetRow{{
+		Exchange:     r.Exchange,
+		Pair:         r.Pair,
+		Period:       r.Period.String(),
+		PeriodNumber: int32(r.PeriodNumber),
+		PriceType:    r.PriceType.String(),
+		Time:         r.Time.UnixMilli(),
+		Value:        r.Value,
+	}})
+	return err
+}
+
+// Close writes the remaining rows and the file metadata.
+func (p *parquetWriter) Close() error {
+	return p.w.Close()
+}
@@ -0,0 +1,152 @@
+// Package gateway exposes the SMA service over HTTP/JSON for consumers that
+// don't use Temporal.
+package gateway
+
+import (
+	"context"
+	_ "embed" // Embed the OpenAPI specification
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/clients"
+)
+
+// OpenAPI is the OpenAPI specification of the gateway.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
+
+// ErrorResponse is the body of the responses of failed requests.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Gateway is an HTTP handler calling the SMA service through a client.
+type Gateway struct {
+	client clients.Client
+	mux    *http.ServeMux
+}
+
+// New creates a new gateway backed by the given client.
+func New(cl clients.Client) *Gateway {
+	g := &Gateway{
+		client: cl,
+		mux:    http.NewServeMux(),
+	}
+
+	g.mux.HandleFunc("GET /sma", g.listSMA)
+	g.mux.HandleFunc("GET /info", g.info)
+	g.mux.HandleFunc("GET /openapi.yaml", g.openAPI)
+
+	return g
+}
+
+// ServeHTTP serves the gateway requests.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+func (g *Gateway) listSMA(w http.ResponseWriter, r *http.Request) {
+	// Get and validate parameters
+	params, err := listParamsFromQuery(r)
+	if err == nil {
+		err = params.Validate()
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Call the service
+	res, err := g.client.List(r.Context(), params)
+	if err != nil {
+		writeError(w, statusFromError(err), err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (g *Gateway) info(w http.ResponseWriter, r *http.Request) {
+	res, err := g.client.Info(r.Context())
+	if err != nil {
+		writeError(w, statusFromError(err), err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (g *Gateway) openAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(OpenAPI)
+}
+
+// listParamsFromQuery parses the list parameters from the query string.
+func listParamsFromQuery(r *http.Request) (params api.ListWorkflowParams, err error) {
+	q := r.URL.Query()
+
+	params = api.ListWorkflowParams{
+		Exchange:  q.Get("exchange"),
+		Pair:      q.Get("pair"),
+		Period:    period.Symbol(q.Get("period")),
+		PriceType: candlestick.PriceType(q.Get("price_type")),
+		Format:    api.ResultFormat(q.Get("format")),
+	}
+
+	if length := q.Get("length"); length != "" {
+		params.PeriodNumber, err = strconv.Atoi(length)
+		if err != nil {
+			return api.ListWorkflowParams{}, fmt.Errorf("invalid length %q: %w", length, err)
+		}
+	}
+
+	if start := q.Get("start"); start != "" {
+		params.Start, err = time.Parse(time.RFC3339, start)
+		if err != nil {
+			return api.ListWorkflowParams{}, fmt.Errorf("invalid start %q: %w", start, err)
+		}
+	}
+
+	if end := q.Get("end"); end != "" {
+		params.End, err = time.Parse(time.RFC3339, end)
+		if err != nil {
+			return api.ListWorkflowParams{}, fmt.Errorf("invalid end %q: %w", end, err)
+		}
+	}
+
+	return params, nil
+}
+
+// statusFromError returns the HTTP status corresponding to an error of the client.
+func statusFromError(err error) int {
+	switch {
+	case clients.IsInvalidArgument(err):
+		return http.StatusBadRequest
+	case clients.IsNotFound(err):
+		return http.StatusNotFound
+	case clients.IsUpstreamUnavailable(err):
+		return http.StatusBadGateway
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
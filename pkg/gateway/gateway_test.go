@@ -0,0 +1,143 @@
+//go:build unit
+// +build unit
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/stretchr/testify/suite"
+)
+
+// failingSource is a candlestick source that always fails.
+type failingSource struct{}
+
+func (failingSource) ListCandlesticks(
+	_ context.Context,
+	_, _ string,
+	_ period.Symbol,
+	_, _ time.Time,
+) ([]candlestick.Candlestick, error) {
+	return nil, errors.New("unavailable")
+}
+
+func TestGatewaySuite(t *testing.T) {
+	suite.Run(t, new(GatewaySuite))
+}
+
+type GatewaySuite struct {
+	suite.Suite
+	server *httptest.Server
+}
+
+func (suite *GatewaySuite) SetupTest() {
+	l := candlestick.NewList("binance", "ETH-USDT", period.M1).
+		MustSet(candlestick.Candlestick{Time: time.Unix(0, 0), Close: 1000}).
+		MustSet(candlestick.Candlestick{Time: time.Unix(60, 0), Close: 1500}).
+		MustSet(candlestick.Candlestick{Time: time.Unix(120, 0), Close: 1250}).
+		MustSet(candlestick.Candlestick{Time: time.Unix(180, 0), Close: 1300})
+	source, err := clients.NewMemorySource(l)
+	suite.Require().NoError(err)
+
+	suite.server = httptest.NewServer(New(clients.NewLocal(source)))
+}
+
+func (suite *GatewaySuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *GatewaySuite) get(path string, body any) int {
+	resp, err := http.Get(suite.server.URL + path)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	if body != nil {
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(body))
+	}
+	return resp.StatusCode
+}
+
+func (suite *GatewaySuite) TestListSMA() {
+	var res api.ListWorkflowResults
+	status := suite.get("/sma?exchange=binance&pair=ETH-USDT&period=M1&length=3&price_type=close"+
+		"&start=1970-01-01T00:02:00Z&end=1970-01-01T00:03:00Z", &res)
+	suite.Require().Equal(http.StatusOK, status)
+	suite.Require().Len(res.Data, 2)
+	suite.Require().Equal(1350.0, res.Data[1].Value)
+}
+
+func (suite *GatewaySuite) TestListSMAErrors() {
+	cases := []struct {
+		Name   string
+		Query  string
+		Status int
+	}{
+		{
+			Name:   "missing parameters",
+			Query:  "exchange=binance",
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid length",
+			Query: "exchange=binance&pair=ETH-USDT&period=M1&length=abc&price_type=close" +
+				"&start=1970-01-01T00:02:00Z&end=1970-01-01T00:03:00Z",
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "invalid period",
+			Query: "exchange=binance&pair=ETH-USDT&period=M2&length=3&price_type=close" +
+				"&start=1970-01-01T00:02:00Z&end=1970-01-01T00:03:00Z",
+			Status: http.StatusBadRequest,
+		},
+		{
+			Name: "not found",
+			Query: "exchange=binance&pair=BTC-USDT&period=M1&length=3&price_type=close" +
+				"&start=1970-01-01T00:02:00Z&end=1970-01-01T00:03:00Z",
+			Status: http.StatusNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		var res ErrorResponse
+		status := suite.get("/sma?"+c.Query, &res)
+		suite.Require().Equal(c.Status, status, c.Name)
+		suite.Require().NotEmpty(res.Error, c.Name)
+	}
+}
+
+func (suite *GatewaySuite) TestListSMAUpstreamUnavailable() {
+	server := httptest.NewServer(New(clients.NewLocal(failingSource{})))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/sma?exchange=binance&pair=ETH-USDT&period=M1&length=3" +
+		"&price_type=close&start=1970-01-01T00:02:00Z&end=1970-01-01T00:03:00Z")
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+	suite.Require().Equal(http.StatusBadGateway, resp.StatusCode)
+}
+
+func (suite *GatewaySuite) TestInfo() {
+	var res api.ServiceInfoResults
+	suite.Require().Equal(http.StatusOK, suite.get("/info", &res))
+	suite.Require().NotEmpty(res.Version)
+}
+
+func (suite *GatewaySuite) TestOpenAPI() {
+	suite.Require().Equal(http.StatusOK, suite.get("/openapi.yaml", nil))
+	suite.Require().Equal(http.StatusMethodNotAllowed, func() int {
+		resp, err := http.Post(suite.server.URL+"/sma", "application/json", nil)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}())
+}
@@ -0,0 +1,196 @@
+// Package grpcserver exposes the SMA service over gRPC, with the workflows
+// still executed by Temporal through a client.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	smav1 "github.com/cryptellation/sma/api/proto/sma/v1"
+	"github.com/cryptellation/sma/pkg/clients"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultStreamChunkSize is the default count of points requested at once
+// when streaming a range.
+const DefaultStreamChunkSize = clients.DefaultListChunkSize
+
+// Option is an option of the server.
+type Option func(s *Server)
+
+// WithStreamChunkSize sets the count of points requested at once when
+// streaming a range.
+func WithStreamChunkSize(size int) Option {
+	return func(s *Server) {
+		s.chunkSize = size
+	}
+}
+
+// Server implements the SMA gRPC service on top of a client.
+type Server struct {
+	smav1.UnimplementedSMAServiceServer
+
+	client    clients.Client
+	chunkSize int
+
+	now   func() time.Time
+	after func(d time.Duration) <-chan time.Time
+}
+
+// New creates a new gRPC server backed by the given client.
+func New(cl clients.Client, opts ...Option) *Server {
+	s := &Server{
+		client:    cl,
+		chunkSize: DefaultStreamChunkSize,
+		now:       time.Now,
+		after:     time.After,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.chunkSize <= 0 {
+		s.chunkSize = DefaultStreamChunkSize
+	}
+
+	return s
+}
+
+// Register registers the service on the gRPC server.
+func (s *Server) Register(gs grpc.ServiceRegistrar) {
+	smav1.RegisterSMAServiceServer(gs, s)
+}
+
+// ListSMA returns the SMA points of a range.
+func (s *Server) ListSMA(
+	ctx context.Context,
+	req *smav1.ListWorkflowParams,
+) (*smav1.ListWorkflowResults, error) {
+	params, err := listParamsFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.List(ctx, params)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return res.ToProto().(*smav1.ListWorkflowResults), nil
+}
+
+// StreamListSMA returns the SMA points of a range as a stream, requesting
+// them by chunks so that large ranges are not loaded at once.
+func (s *Server) StreamListSMA(
+	req *smav1.ListWorkflowParams,
+	stream grpc.ServerStreamingServer[smav1.SMADataPoint],
+) error {
+	params, err := listParamsFromProto(req)
+	if err != nil {
+		return err
+	}
+
+	var sendErr error
+	err = clients.ListChunks(stream.Context(), s.client, params, s.chunkSize,
+		func(_ api.ListWorkflowParams, res api.ListWorkflowResults) error {
+			for _, p := range res.Data {
+				if sendErr = stream.Send(pointToProto(p)); sendErr != nil {
+					return sendErr
+				}
+			}
+			return nil
+		})
+	switch {
+	case sendErr != nil:
+		return sendErr
+	case err != nil:
+		return statusFromError(err)
+	default:
+		return nil
+	}
+}
+
+// WatchSMA streams the SMA point of each period once it is closed, starting
+// with the last closed one (see clients.Watch).
+func (s *Server) WatchSMA(
+	req *smav1.WatchSMARequest,
+	stream grpc.ServerStreamingServer[smav1.SMADataPoint],
+) error {
+	params := api.ListWorkflowParams{
+		Exchange:     req.GetExchange(),
+		Pair:         req.GetPair(),
+		Period:       period.Symbol(req.GetPeriod()),
+		PeriodNumber: int(req.GetPeriodNumber()),
+		PriceType:    candlestick.PriceType(req.GetPriceType()),
+	}
+
+	var sendErr error
+	err := clients.Watch(stream.Context(), s.client, params, func(p api.SMADataPoint) error {
+		sendErr = stream.Send(pointToProto(p))
+		return sendErr
+	}, clients.WithWatchClock(s.now, s.after))
+	switch {
+	case sendErr != nil:
+		return sendErr
+	case err != nil:
+		return statusFromError(err)
+	default:
+		return nil
+	}
+}
+
+// Info returns the service information.
+func (s *Server) Info(ctx context.Context, _ *smav1.ServiceInfoParams) (*smav1.ServiceInfoResults, error) {
+	res, err := s.client.Info(ctx)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return res.ToProto().(*smav1.ServiceInfoResults), nil
+}
+
+// listParamsFromProto converts and validates the list parameters.
+func listParamsFromProto(req *smav1.ListWorkflowParams) (api.ListWorkflowParams, error) {
+	var params api.ListWorkflowParams
+	if err := params.FromProto(req); err != nil {
+		return api.ListWorkflowParams{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := params.Validate(); err != nil {
+		return api.ListWorkflowParams{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return params, nil
+}
+
+func pointToProto(p api.SMADataPoint) *smav1.SMADataPoint {
+	return &smav1.SMADataPoint{
+		Time:  timestamppb.New(p.Time),
+		Value: p.Value,
+	}
+}
+
+// statusFromError returns the gRPC status corresponding to an error of the client.
+func statusFromError(err error) error {
+	switch {
+	case clients.IsInvalidArgument(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case clients.IsNotFound(err):
+		return status.Error(codes.NotFound, err.Error())
+	case clients.IsUpstreamUnavailable(err):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,161 @@
+//go:build unit
+// +build unit
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	smav1 "github.com/cryptellation/sma/api/proto/sma/v1"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerSuite))
+}
+
+type ServerSuite struct {
+	suite.Suite
+	server     *Server
+	grpcServer *grpc.Server
+	conn       *grpc.ClientConn
+	client     smav1.SMAServiceClient
+
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func (suite *ServerSuite) SetupTest() {
+	l := candlestick.NewList("binance", "ETH-USDT", period.M1)
+	for i, c := range []float64{1000, 1500, 1250, 1300, 1400, 1600} {
+		l.MustSet(candlestick.Candlestick{Time: time.Unix(int64(i*60), 0), Close: c})
+	}
+	source, err := clients.NewMemorySource(l)
+	suite.Require().NoError(err)
+
+	// The candlestick at 00:03 is the last closed one
+	suite.now = time.Unix(250, 0)
+	suite.server = New(clients.NewLocal(source), WithStreamChunkSize(2))
+	suite.server.now = func() time.Time {
+		suite.mutex.Lock()
+		defer suite.mutex.Unlock()
+		return suite.now
+	}
+	suite.server.after = func(d time.Duration) <-chan time.Time {
+		// Jump to the requested time
+		suite.mutex.Lock()
+		defer suite.mutex.Unlock()
+		suite.now = suite.now.Add(d)
+		ch := make(chan time.Time, 1)
+		ch <- suite.now
+		return ch
+	}
+
+	// Serve in-process
+	lis := bufconn.Listen(1024 * 1024)
+	suite.grpcServer = grpc.NewServer()
+	suite.server.Register(suite.grpcServer)
+	go func() { _ = suite.grpcServer.Serve(lis) }()
+
+	suite.conn, err = grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	suite.Require().NoError(err)
+	suite.client = smav1.NewSMAServiceClient(suite.conn)
+}
+
+func (suite *ServerSuite) TearDownTest() {
+	suite.Require().NoError(suite.conn.Close())
+	suite.grpcServer.Stop()
+}
+
+func (suite *ServerSuite) listRequest(start, end int64) *smav1.ListWorkflowParams {
+	return &smav1.ListWorkflowParams{
+		Exchange:     "binance",
+		Pair:         "ETH-USDT",
+		Period:       "M1",
+		Start:        timestamppb.New(time.Unix(start, 0)),
+		End:          timestamppb.New(time.Unix(end, 0)),
+		PeriodNumber: 3,
+		PriceType:    "close",
+	}
+}
+
+func (suite *ServerSuite) TestListSMA() {
+	res, err := suite.client.ListSMA(context.Background(), suite.listRequest(120, 180))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.GetData(), 2)
+	suite.Require().Equal(1350.0, res.GetData()[1].GetValue())
+}
+
+func (suite *ServerSuite) TestListSMAErrors() {
+	invalid := suite.listRequest(120, 180)
+	invalid.Pair = "ETHUSDT"
+	_, err := suite.client.ListSMA(context.Background(), invalid)
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	unknown := suite.listRequest(120, 180)
+	unknown.Pair = "BTC-USDT"
+	_, err = suite.client.ListSMA(context.Background(), unknown)
+	suite.Require().Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *ServerSuite) TestStreamListSMA() {
+	stream, err := suite.client.StreamListSMA(context.Background(), suite.listRequest(120, 300))
+	suite.Require().NoError(err)
+
+	var times []int64
+	for {
+		p, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		suite.Require().NoError(err)
+		times = append(times, p.GetTime().AsTime().Unix())
+	}
+	suite.Require().Equal([]int64{120, 180, 240, 300}, times)
+}
+
+func (suite *ServerSuite) TestWatchSMA() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := suite.client.WatchSMA(ctx, &smav1.WatchSMARequest{
+		Exchange:     "binance",
+		Pair:         "ETH-USDT",
+		Period:       "M1",
+		PeriodNumber: 3,
+		PriceType:    "close",
+	})
+	suite.Require().NoError(err)
+
+	// Last closed point, then the points of the next closed periods
+	for _, expected := range []int64{180, 240, 300} {
+		p, err := stream.Recv()
+		suite.Require().NoError(err)
+		suite.Require().Equal(expected, p.GetTime().AsTime().Unix())
+	}
+}
+
+func (suite *ServerSuite) TestInfo() {
+	res, err := suite.client.Info(context.Background(), &smav1.ServiceInfoParams{})
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(res.GetVersion())
+}
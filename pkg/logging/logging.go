@@ -0,0 +1,95 @@
+// Package logging creates the structured loggers of the service.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/log"
+)
+
+// ErrUnknownFormat is returned when the log format is not supported.
+var ErrUnknownFormat = errors.New("unknown log format")
+
+// Format is the format of the log lines.
+type Format string
+
+const (
+	// FormatText writes the log lines as key=value pairs.
+	FormatText Format = "text"
+	// FormatJSON writes the log lines as JSON objects.
+	FormatJSON Format = "json"
+)
+
+// Keys of the fields describing a SMA series, set on every log line about it.
+const (
+	KeyExchange     = "exchange"
+	KeyPair         = "pair"
+	KeyPeriod       = "period"
+	KeyPeriodNumber = "period_number"
+	KeyPriceType    = "price_type"
+)
+
+// New creates a logger writing to w with the level (debug, info, warn or
+// error) and the format.
+func New(w io.Writer, level string, format Format) (*slog.Logger, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: l}
+
+	switch format {
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, string(format))
+	}
+}
+
+// NewTemporalLogger wraps the logger to be used by the Temporal client and
+// workers, so that the workflows and activities log lines go through it.
+func NewTemporalLogger(logger *slog.Logger) log.Logger {
+	return log.NewStructuredLogger(logger)
+}
+
+// WithSeries adds the fields describing the series to the logger.
+func WithSeries(
+	logger log.Logger,
+	exchange, pair string,
+	per period.Symbol,
+	periodNumber int,
+	priceType candlestick.PriceType,
+) log.Logger {
+	return log.With(logger,
+		KeyExchange, exchange,
+		KeyPair, pair,
+		KeyPeriod, per.String(),
+		KeyPeriodNumber, periodNumber,
+		KeyPriceType, priceType.String())
+}
+
+// SeriesLogger returns the logger of the activity with the fields of the
+// series, or the default logger if the context is not an activity one.
+func SeriesLogger(
+	ctx context.Context,
+	exchange, pair string,
+	per period.Symbol,
+	periodNumber int,
+	priceType candlestick.PriceType,
+) log.Logger {
+	logger := NewTemporalLogger(slog.Default())
+	if activity.IsActivity(ctx) {
+		logger = activity.GetLogger(ctx)
+	}
+
+	return WithSeries(logger, exchange, pair, per, periodNumber, priceType)
+}
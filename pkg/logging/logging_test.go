@@ -0,0 +1,60 @@
+//go:build unit
+// +build unit
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLoggingSuite(t *testing.T) {
+	suite.Run(t, new(LoggingSuite))
+}
+
+type LoggingSuite struct {
+	suite.Suite
+}
+
+func (suite *LoggingSuite) TestNewWithSeries() {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", FormatJSON)
+	suite.Require().NoError(err)
+
+	l := WithSeries(NewTemporalLogger(logger), "binance", "BTC-USDT", period.H1, 20, candlestick.PriceTypeIsClose)
+	l.Debug("hidden")
+	l.Info("visible", "count", 3)
+
+	var line map[string]any
+	suite.Require().NoError(json.Unmarshal(buf.Bytes(), &line))
+	suite.Require().Equal("visible", line["msg"])
+	suite.Require().Equal("INFO", line["level"])
+	suite.Require().Equal("binance", line[KeyExchange])
+	suite.Require().Equal("BTC-USDT", line[KeyPair])
+	suite.Require().Equal("H1", line[KeyPeriod])
+	suite.Require().Equal(float64(20), line[KeyPeriodNumber])
+	suite.Require().Equal("close", line[KeyPriceType])
+	suite.Require().Equal(float64(3), line["count"])
+}
+
+func (suite *LoggingSuite) TestNewText() {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "debug", FormatText)
+	suite.Require().NoError(err)
+
+	logger.Debug("visible", KeyPair, "ETH-USDT")
+	suite.Require().Contains(buf.String(), "level=DEBUG msg=visible pair=ETH-USDT")
+}
+
+func (suite *LoggingSuite) TestNewErrors() {
+	_, err := New(&bytes.Buffer{}, "verbose", FormatText)
+	suite.Require().Error(err)
+
+	_, err = New(&bytes.Buffer{}, "info", Format("xml"))
+	suite.Require().ErrorIs(err, ErrUnknownFormat)
+}
@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// RecordQueryLatency records the latency of the database query started at the
+// given time, when run as an activity. It is expected to be called right after
+// the SQL call, so that the processing of the results is not recorded.
+func RecordQueryLatency(ctx context.Context, query string, start time.Time) {
+	if !activity.IsActivity(ctx) {
+		return
+	}
+
+	activity.GetMetricsHandler(ctx).
+		WithTags(map[string]string{TagQuery: query}).
+		Timer(DBQueryTimer).Record(time.Since(start))
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uber-go/tally/v4"
+	tallyprom "github.com/uber-go/tally/v4/prometheus"
+	"go.temporal.io/sdk/client"
+	sdktally "go.temporal.io/sdk/contrib/tally"
+)
+
+// DefaultTimerBuckets are the buckets, in seconds, of the histograms
+// corresponding to the timers, from 1ms to about 9min.
+var DefaultTimerBuckets = prometheus.ExponentialBuckets(0.001, 2, 20)
+
+// reportInterval is the interval at which the metrics are reported to the
+// Prometheus registry.
+const reportInterval = time.Second
+
+// NewHandler creates a Temporal metrics handler that reports the metrics to
+// the Prometheus registerer, with the Prometheus naming conventions of the
+// Temporal SDK: timers are histograms in seconds, with DefaultTimerBuckets if
+// no buckets are given, and the "_seconds" suffix.
+//
+// The returned closer reports the last metrics and stops the reporting.
+func NewHandler(reg prometheus.Registerer, timerBuckets ...float64) (client.MetricsHandler, io.Closer) {
+	if len(timerBuckets) == 0 {
+		timerBuckets = DefaultTimerBuckets
+	}
+
+	reporter := tallyprom.NewReporter(tallyprom.Options{
+		Registerer:              reg,
+		DefaultTimerType:        tallyprom.HistogramTimerType,
+		DefaultHistogramBuckets: timerBuckets,
+		OnRegisterError: func(err error) {
+			slog.Warn("Registering metric failed", "error", err)
+		},
+	})
+	scope, closer := tally.NewRootScope(tally.ScopeOptions{
+		CachedReporter:  reporter,
+		Separator:       tallyprom.DefaultSeparator,
+		SanitizeOptions: &sdktally.PrometheusSanitizeOptions,
+	}, reportInterval)
+
+	return sdktally.NewMetricsHandler(sdktally.NewPrometheusNamingScope(scope)), closer
+}
@@ -0,0 +1,55 @@
+//go:build unit
+// +build unit
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestHandlerSuite(t *testing.T) {
+	suite.Run(t, new(HandlerSuite))
+}
+
+type HandlerSuite struct {
+	suite.Suite
+}
+
+func (suite *HandlerSuite) TestReport() {
+	reg := prometheus.NewRegistry()
+	h, closer := NewHandler(reg, 0.1, 1)
+
+	// WHEN emitting metrics and closing the handler
+	h.WithTags(map[string]string{TagResult: ResultHit}).Counter(ListRequestsCounter).Inc(2)
+	h.WithTags(map[string]string{TagResult: ResultHit}).Counter(ListRequestsCounter).Inc(1)
+	h.WithTags(map[string]string{TagResult: ResultRecompute}).Counter(ListRequestsCounter).Inc(1)
+	h.Gauge("temporal_num_pollers").Update(4)
+	h.Timer(CandlesticksFetchTimer).Record(500 * time.Millisecond)
+	suite.Require().NoError(closer.Close())
+
+	// THEN they are reported with the Prometheus naming
+	expected := `
+# HELP sma_candlesticks_fetch_latency_seconds sma_candlesticks_fetch_latency_seconds histogram
+# TYPE sma_candlesticks_fetch_latency_seconds histogram
+sma_candlesticks_fetch_latency_seconds_bucket{le="0.1"} 0
+sma_candlesticks_fetch_latency_seconds_bucket{le="1"} 1
+sma_candlesticks_fetch_latency_seconds_bucket{le="+Inf"} 1
+sma_candlesticks_fetch_latency_seconds_sum 0.5
+sma_candlesticks_fetch_latency_seconds_count 1
+# HELP sma_list_requests_total sma_list_requests_total counter
+# TYPE sma_list_requests_total counter
+sma_list_requests_total{result="hit"} 3
+sma_list_requests_total{result="recompute"} 1
+# HELP temporal_num_pollers temporal_num_pollers gauge
+# TYPE temporal_num_pollers gauge
+temporal_num_pollers 4
+`
+	suite.Require().NoError(testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"sma_candlesticks_fetch_latency_seconds", "sma_list_requests_total", "temporal_num_pollers"))
+}
@@ -0,0 +1,42 @@
+// Package metrics exposes the metrics of the SMA worker in the Prometheus
+// format.
+//
+// The workflows and activities emit their metrics through the Temporal SDK
+// metrics handler, so that nothing is emitted during workflow replays. The
+// handler of this package reports them to Prometheus through tally, along
+// with the SDK metrics (workflow counts and latencies by outcome, pollers,
+// task slots, etc.). Counters are exposed with the "_total" suffix and timers
+// as histograms in seconds with the "_seconds" suffix.
+package metrics
+
+// Names of the metrics emitted by the SMA workflows and activities.
+const (
+	// ListRequestsCounter counts the list requests, tagged by result
+	// (ResultHit or ResultRecompute).
+	ListRequestsCounter = "sma_list_requests"
+	// PointsReadCounter counts the SMA points read from the database.
+	PointsReadCounter = "sma_points_read"
+	// PointsUpsertedCounter counts the SMA points upserted in the database.
+	PointsUpsertedCounter = "sma_points_upserted"
+	// CandlesticksFetchTimer records the latency of the candlesticks requests.
+	CandlesticksFetchTimer = "sma_candlesticks_fetch_latency"
+	// DBQueryTimer records the latency of the database queries, tagged by
+	// query (TagQuery).
+	DBQueryTimer = "sma_db_query_latency"
+)
+
+// Tags of the metrics emitted by the SMA workflows and activities.
+const (
+	// TagResult is the tag of the result of a list request.
+	TagResult = "result"
+	// TagQuery is the tag of the database query.
+	TagQuery = "query"
+)
+
+// Values of the TagResult tag.
+const (
+	// ResultHit is set when the points were up to date in the database.
+	ResultHit = "hit"
+	// ResultRecompute is set when the points had to be recomputed.
+	ResultRecompute = "recompute"
+)
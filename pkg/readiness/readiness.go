@@ -0,0 +1,148 @@
+// Package readiness periodically checks the dependencies of the service to
+// report whether it is ready to handle requests.
+package readiness
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	// DefaultInterval is the default interval between two checks rounds.
+	DefaultInterval = 10 * time.Second
+	// DefaultTimeout is the default timeout of a single check.
+	DefaultTimeout = 5 * time.Second
+	// DefaultFailureThreshold is the default count of consecutive failures of
+	// a check before the service is not ready anymore.
+	DefaultFailureThreshold = 3
+)
+
+// Check checks a dependency, returning an error if it is not available.
+type Check func(ctx context.Context) error
+
+// Option is an option of the monitor.
+type Option func(m *Monitor)
+
+// WithInterval sets the interval between two checks rounds.
+func WithInterval(interval time.Duration) Option {
+	return func(m *Monitor) {
+		m.interval = interval
+	}
+}
+
+// WithTimeout sets the timeout of a single check.
+func WithTimeout(timeout time.Duration) Option {
+	return func(m *Monitor) {
+		m.timeout = timeout
+	}
+}
+
+// WithFailureThreshold sets the count of consecutive failures of a check
+// before the service is not ready anymore, so that a transient error does not
+// remove it from the load balancing.
+func WithFailureThreshold(threshold int) Option {
+	return func(m *Monitor) {
+		m.failureThreshold = threshold
+	}
+}
+
+// WithCheck adds a named check to the monitor.
+func WithCheck(name string, check Check) Option {
+	return func(m *Monitor) {
+		m.checks = append(m.checks, namedCheck{name: name, check: check})
+	}
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Monitor runs the checks periodically and sets the readiness accordingly.
+type Monitor struct {
+	setReady         func(ready bool)
+	checks           []namedCheck
+	failures         []int
+	ready            bool
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int
+}
+
+// New creates a monitor setting the readiness with setReady (e.g. the Ready
+// method of the health server).
+func New(setReady func(ready bool), opts ...Option) *Monitor {
+	m := &Monitor{
+		setReady:         setReady,
+		interval:         DefaultInterval,
+		timeout:          DefaultTimeout,
+		failureThreshold: DefaultFailureThreshold,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.failureThreshold < 1 {
+		m.failureThreshold = 1
+	}
+
+	// Checks are failing until they first succeed, so that the service is not
+	// ready before its dependencies are
+	m.failures = make([]int, len(m.checks))
+	for i := range m.failures {
+		m.failures[i] = m.failureThreshold
+	}
+
+	return m
+}
+
+// Run runs the checks until the context is done, then sets the service as
+// not ready.
+func (m *Monitor) Run(ctx context.Context) error {
+	defer m.setReady(false)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.CheckOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce runs every check once, updates the readiness and returns it.
+func (m *Monitor) CheckOnce(ctx context.Context) bool {
+	ready := true
+	for i, c := range m.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, m.timeout)
+		err := c.check(checkCtx)
+		cancel()
+
+		if err != nil {
+			m.failures[i]++
+			slog.Warn("Readiness check failed",
+				"check", c.name,
+				"consecutive_failures", m.failures[i],
+				"error", err)
+		} else {
+			m.failures[i] = 0
+		}
+
+		if m.failures[i] >= m.failureThreshold {
+			ready = false
+		}
+	}
+
+	if ready != m.ready {
+		slog.Info("Readiness changed", "ready", ready)
+	}
+	m.ready = ready
+	m.setReady(ready)
+
+	return ready
+}
@@ -0,0 +1,147 @@
+//go:build unit
+// +build unit
+
+package readiness
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/grpc"
+)
+
+func TestReadinessSuite(t *testing.T) {
+	suite.Run(t, new(ReadinessSuite))
+}
+
+type ReadinessSuite struct {
+	suite.Suite
+	ready bool
+}
+
+func (suite *ReadinessSuite) SetupTest() {
+	suite.ready = false
+}
+
+func (suite *ReadinessSuite) setReady(ready bool) {
+	suite.ready = ready
+}
+
+func (suite *ReadinessSuite) TestCheckOnce() {
+	var dbErr error
+	m := New(suite.setReady,
+		WithFailureThreshold(2),
+		WithCheck("database", func(_ context.Context) error { return dbErr }),
+		WithCheck("temporal", func(_ context.Context) error { return nil }))
+
+	// Ready as soon as every check succeeds
+	suite.Require().True(m.CheckOnce(context.Background()))
+	suite.Require().True(suite.ready)
+
+	// Still ready below the failure threshold
+	dbErr = errors.New("connection refused")
+	suite.Require().True(m.CheckOnce(context.Background()))
+	suite.Require().True(suite.ready)
+
+	// Not ready once the threshold is reached
+	suite.Require().False(m.CheckOnce(context.Background()))
+	suite.Require().False(suite.ready)
+
+	// Ready again after a success
+	dbErr = nil
+	suite.Require().True(m.CheckOnce(context.Background()))
+	suite.Require().True(suite.ready)
+}
+
+func (suite *ReadinessSuite) TestCheckOnceNotReadyBeforeFirstSuccess() {
+	m := New(suite.setReady,
+		WithFailureThreshold(3),
+		WithCheck("database", func(_ context.Context) error { return errors.New("connection refused") }))
+
+	suite.Require().False(m.CheckOnce(context.Background()))
+	suite.Require().False(suite.ready)
+}
+
+func (suite *ReadinessSuite) TestCheckOnceTimeout() {
+	m := New(suite.setReady,
+		WithFailureThreshold(1),
+		WithTimeout(time.Millisecond),
+		WithCheck("temporal", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+
+	suite.Require().False(m.CheckOnce(context.Background()))
+}
+
+func (suite *ReadinessSuite) TestRun() {
+	ready := make(chan bool, 10)
+	m := New(func(r bool) { ready <- r },
+		WithInterval(time.Hour),
+		WithCheck("database", func(_ context.Context) error { return nil }))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- m.Run(ctx) }()
+
+	suite.Require().True(<-ready)
+	cancel()
+	suite.Require().ErrorIs(<-errCh, context.Canceled)
+
+	// The last readiness set is not ready
+	var last bool
+	for len(ready) > 0 {
+		last = <-ready
+	}
+	suite.Require().False(last)
+}
+
+// temporalClient answers the health and namespace requests of TemporalCheck.
+type temporalClient struct {
+	client.Client
+	healthErr error
+	service   workflowService
+}
+
+func (c *temporalClient) CheckHealth(
+	context.Context,
+	*client.CheckHealthRequest,
+) (*client.CheckHealthResponse, error) {
+	return &client.CheckHealthResponse{}, c.healthErr
+}
+
+func (c *temporalClient) WorkflowService() workflowservice.WorkflowServiceClient {
+	return &c.service
+}
+
+// workflowService records the described namespaces.
+type workflowService struct {
+	workflowservice.WorkflowServiceClient
+	namespaces []string
+}
+
+func (s *workflowService) DescribeNamespace(
+	_ context.Context,
+	req *workflowservice.DescribeNamespaceRequest,
+	_ ...grpc.CallOption,
+) (*workflowservice.DescribeNamespaceResponse, error) {
+	s.namespaces = append(s.namespaces, req.GetNamespace())
+	return &workflowservice.DescribeNamespaceResponse{}, nil
+}
+
+func (suite *ReadinessSuite) TestTemporalCheck() {
+	// The namespace of the client is checked
+	cl := &temporalClient{}
+	suite.Require().NoError(TemporalCheck(cl, "staging")(context.Background()))
+	suite.Require().Equal([]string{"staging"}, cl.service.namespaces)
+
+	// The namespace is not checked if the server is not serving
+	cl = &temporalClient{healthErr: errors.New("unavailable")}
+	suite.Require().ErrorIs(TemporalCheck(cl, "staging")(context.Background()), cl.healthErr)
+	suite.Require().Empty(cl.service.namespaces)
+}
@@ -0,0 +1,23 @@
+package readiness
+
+import (
+	"context"
+
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// TemporalCheck returns the check that the Temporal server is serving and that
+// the namespace the client was dialed with exists.
+func TemporalCheck(temporalClient client.Client, namespace string) Check {
+	return func(ctx context.Context) error {
+		if _, err := temporalClient.CheckHealth(ctx, &client.CheckHealthRequest{}); err != nil {
+			return err
+		}
+
+		_, err := temporalClient.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+			Namespace: namespace,
+		})
+		return err
+	}
+}
@@ -1,9 +1,11 @@
 package sma
 
 import (
+	"math"
 	"time"
 
 	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/sma/api"
 	timeserie "github.com/cryptellation/timeseries"
 )
 
@@ -55,6 +57,30 @@ func TimeSerie(params TimeSerieParams) (*timeserie.TimeSerie[float64], error) {
 	return ts, nil
 }
 
+// DataPoints returns the calculated points as a slice ordered by time, as
+// returned by the list workflow.
+func DataPoints(params TimeSerieParams) ([]api.SMADataPoint, error) {
+	ts, err := TimeSerie(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert timeserie to slice of structs
+	data := make([]api.SMADataPoint, 0, ts.Len())
+	err = ts.Loop(func(t time.Time, v float64) (bool, error) {
+		data = append(data, api.SMADataPoint{
+			Time:  t,
+			Value: v,
+		})
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
 // InvalidValues returns true if there is at least one invalid value in the timeserie.
 func InvalidValues(ts *timeserie.TimeSerie[float64]) bool {
 	invalidValuesDetected := false
@@ -67,3 +93,45 @@ func InvalidValues(ts *timeserie.TimeSerie[float64]) bool {
 	})
 	return invalidValuesDetected
 }
+
+// InvalidRanges returns the time ranges of consecutive invalid values in the timeserie.
+func InvalidRanges(ts *timeserie.TimeSerie[float64], interval time.Duration) []timeserie.TimeRange {
+	invalidTimes := make([]time.Time, 0)
+	_ = ts.Loop(func(t time.Time, v float64) (bool, error) {
+		if v == 0 {
+			invalidTimes = append(invalidTimes, t)
+		}
+		return false, nil
+	})
+	return timeserie.TimeRangesFromMissingTimes(interval, invalidTimes)
+}
+
+// Mismatch is a stored point whose value differs from the recomputed one.
+type Mismatch struct {
+	Time     time.Time
+	Stored   float64
+	Computed float64
+}
+
+// Compare returns the points of the stored timeserie that differ by more than
+// the tolerance from the computed one. Points that are not in the computed
+// timeserie are ignored.
+func Compare(stored, computed *timeserie.TimeSerie[float64], tolerance float64) []Mismatch {
+	mismatches := make([]Mismatch, 0)
+	_ = stored.Loop(func(t time.Time, v float64) (bool, error) {
+		c, exists := computed.Get(t)
+		if !exists {
+			return false, nil
+		}
+
+		if math.IsNaN(v) != math.IsNaN(c) || math.Abs(v-c) > tolerance {
+			mismatches = append(mismatches, Mismatch{
+				Time:     t,
+				Stored:   v,
+				Computed: c,
+			})
+		}
+		return false, nil
+	})
+	return mismatches
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cryptellation/candlesticks/pkg/candlestick"
 	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
 	timeserie "github.com/cryptellation/timeseries"
 	"github.com/stretchr/testify/suite"
 )
@@ -58,6 +59,25 @@ func (suite *TimeSerieSuite) TestTimeSerieSuite() {
 	}
 }
 
+func (suite *TimeSerieSuite) TestDataPoints() {
+	data, err := DataPoints(TimeSerieParams{
+		Candlesticks: candlestick.NewList("exchange", "ETH-USDC", period.M1).
+			MustSet(candlestick.Candlestick{Time: time.Unix(0, 0), Close: 1000}).
+			MustSet(candlestick.Candlestick{Time: time.Unix(60, 0), Close: 1500}).
+			MustSet(candlestick.Candlestick{Time: time.Unix(120, 0), Close: 1250}).
+			MustSet(candlestick.Candlestick{Time: time.Unix(180, 0), Close: 1300}),
+		PriceType:    candlestick.PriceTypeIsClose,
+		Start:        time.Unix(120, 0),
+		End:          time.Unix(180, 0),
+		PeriodNumber: 3,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]api.SMADataPoint{
+		{Time: time.Unix(120, 0), Value: 1250},
+		{Time: time.Unix(180, 0), Value: 1350},
+	}, data)
+}
+
 func (suite *TimeSerieSuite) TestInvalidValues() {
 	cases := []struct {
 		Params         *timeserie.TimeSerie[float64]
@@ -85,3 +105,37 @@ func (suite *TimeSerieSuite) TestInvalidValues() {
 		suite.Require().Equal(c.ExpectedOutput, InvalidValues(c.Params), i)
 	}
 }
+
+func (suite *TimeSerieSuite) TestInvalidRanges() {
+	ts := timeserie.New[float64]().
+		Set(time.Unix(0, 0), 0).
+		Set(time.Unix(60, 0), 1).
+		Set(time.Unix(120, 0), 0).
+		Set(time.Unix(180, 0), 0).
+		Set(time.Unix(240, 0), 2)
+
+	suite.Require().Equal([]timeserie.TimeRange{
+		{Start: time.Unix(0, 0), End: time.Unix(0, 0)},
+		{Start: time.Unix(120, 0), End: time.Unix(180, 0)},
+	}, InvalidRanges(ts, time.Minute))
+}
+
+func (suite *TimeSerieSuite) TestCompare() {
+	stored := timeserie.New[float64]().
+		Set(time.Unix(0, 0), 1000).
+		Set(time.Unix(60, 0), 1500.0001).
+		Set(time.Unix(120, 0), 1250).
+		Set(time.Unix(180, 0), 1300)
+	computed := timeserie.New[float64]().
+		Set(time.Unix(0, 0), 1000).
+		Set(time.Unix(60, 0), 1500).
+		Set(time.Unix(120, 0), 1200)
+
+	// Small difference is tolerated, point not computed is ignored
+	suite.Require().Equal([]Mismatch{
+		{Time: time.Unix(120, 0), Stored: 1250, Computed: 1200},
+	}, Compare(stored, computed, 0.001))
+
+	// Exact comparison
+	suite.Require().Len(Compare(stored, computed, 0), 2)
+}
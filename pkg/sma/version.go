@@ -0,0 +1,6 @@
+package sma
+
+// AlgorithmVersion is the version of the SMA computation algorithm.
+// It must be incremented each time a change to this package modifies the
+// computed values, so that previously stored points are considered stale.
+const AlgorithmVersion = 1
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Setup sets the global tracer provider exporting the spans of the service to
+// the OTLP gRPC endpoint (e.g. "http://localhost:4317", the scheme setting the
+// transport security), and returns the function flushing and stopping it.
+//
+// If the endpoint is empty, the global tracer provider is left as a no-op and
+// no span is exported.
+func Setup(ctx context.Context, serviceName, endpoint string) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	// Create exporter
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	// Create resource describing the service
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	// Create and set provider
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res))
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
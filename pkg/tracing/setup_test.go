@@ -0,0 +1,94 @@
+//go:build unit
+// +build unit
+
+package tracing
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+)
+
+// collector is a local stand-in of an OTLP collector, keeping the received spans.
+type collector struct {
+	coltracepb.UnimplementedTraceServiceServer
+	mu    sync.Mutex
+	spans []*tracepb.ResourceSpans
+}
+
+func (c *collector) Export(
+	_ context.Context,
+	req *coltracepb.ExportTraceServiceRequest,
+) (*coltracepb.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spans = append(c.spans, req.ResourceSpans...)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func TestSetupSuite(t *testing.T) {
+	suite.Run(t, new(SetupSuite))
+}
+
+type SetupSuite struct {
+	suite.Suite
+	collector *collector
+	server    *grpc.Server
+	endpoint  string
+}
+
+func (suite *SetupSuite) SetupTest() {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	suite.collector = &collector{}
+	suite.server = grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(suite.server, suite.collector)
+	go func() { _ = suite.server.Serve(lis) }()
+
+	suite.endpoint = "http://" + lis.Addr().String()
+}
+
+func (suite *SetupSuite) TearDownTest() {
+	suite.server.Stop()
+}
+
+func (suite *SetupSuite) TestSetup() {
+	shutdown, err := Setup(context.Background(), "sma-test", suite.endpoint)
+	suite.Require().NoError(err)
+
+	_, span := otel.Tracer(InstrumentationName).Start(context.Background(), "test")
+	span.End()
+	suite.Require().NoError(shutdown(context.Background()))
+
+	suite.Require().Len(suite.collector.spans, 1)
+	rs := suite.collector.spans[0]
+	suite.Require().Contains(rs.Resource.Attributes, &commonpb.KeyValue{
+		Key:   "service.name",
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "sma-test"}},
+	})
+	suite.Require().Len(rs.ScopeSpans, 1)
+	suite.Require().Equal(InstrumentationName, rs.ScopeSpans[0].Scope.Name)
+	suite.Require().Len(rs.ScopeSpans[0].Spans, 1)
+	suite.Require().Equal("test", rs.ScopeSpans[0].Spans[0].Name)
+}
+
+func (suite *SetupSuite) TestSetupWithoutEndpoint() {
+	shutdown, err := Setup(context.Background(), "sma-test", "")
+	suite.Require().NoError(err)
+
+	_, span := otel.Tracer(InstrumentationName).Start(context.Background(), "test")
+	span.End()
+	suite.Require().False(span.IsRecording())
+	suite.Require().NoError(shutdown(context.Background()))
+
+	suite.Require().Empty(suite.collector.spans)
+}
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/sdk/contrib/opentelemetry"
+	"go.temporal.io/sdk/interceptor"
+)
+
+// InstrumentationName is the name of the tracer used by the service.
+const InstrumentationName = "github.com/cryptellation/sma"
+
+// spanContextKey is the key of the span in the workflow context.
+type spanContextKey struct{}
+
+// Option is an option of the tracing interceptor.
+type Option func(opts *opentelemetry.TracerOptions)
+
+// WithTracerProvider sets the tracer provider used to create the spans,
+// instead of the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(opts *opentelemetry.TracerOptions) {
+		opts.Tracer = tp.Tracer(InstrumentationName)
+	}
+}
+
+// NewInterceptor creates a Temporal interceptor that creates spans for the
+// workflows and activities and propagates them through the Temporal headers,
+// with the OpenTelemetry tracer of the Temporal SDK. It should be set on the
+// Temporal client, so that the workers created from it use it too.
+func NewInterceptor(opts ...Option) (interceptor.Interceptor, error) {
+	options := opentelemetry.TracerOptions{
+		Tracer:         otel.GetTracerProvider().Tracer(InstrumentationName),
+		SpanContextKey: spanContextKey{},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return opentelemetry.NewTracingInterceptor(options)
+}
+
+// StartSpan starts a span from the global tracer provider, and returns the
+// context carrying it along with the function ending it.
+func StartSpan(ctx context.Context, name string) (context.Context, func(err error)) {
+	ctx, s := otel.Tracer(InstrumentationName).Start(ctx, name)
+	return ctx, func(err error) {
+		end(s, err)
+	}
+}
+
+// end ends the span, recording the error if any.
+func end(s trace.Span, err error) {
+	if err != nil {
+		s.RecordError(err)
+		s.SetStatus(codes.Error, err.Error())
+	}
+	s.End()
+}
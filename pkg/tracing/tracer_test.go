@@ -0,0 +1,83 @@
+//go:build unit
+// +build unit
+
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestTracerSuite(t *testing.T) {
+	suite.Run(t, new(TracerSuite))
+}
+
+type TracerSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	provider *sdktrace.TracerProvider
+}
+
+func (suite *TracerSuite) SetupTest() {
+	suite.recorder = tracetest.NewSpanRecorder()
+	suite.provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.recorder))
+}
+
+func (suite *TracerSuite) spans() map[string]sdktrace.ReadOnlySpan {
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range suite.recorder.Ended() {
+		spans[s.Name()] = s
+	}
+	return spans
+}
+
+func (suite *TracerSuite) TestStartWorkflowSpan() {
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestWorkflowEnvironment()
+	env.SetStartTime(time.Unix(60, 0))
+	i, err := NewInterceptor(WithTracerProvider(suite.provider))
+	suite.Require().NoError(err)
+	env.SetWorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{i},
+	})
+
+	env.RegisterActivityWithOptions(func(_ context.Context) error {
+		return nil
+	}, activity.RegisterOptions{Name: "TestActivity"})
+
+	env.RegisterWorkflowWithOptions(func(ctx workflow.Context) error {
+		ctx, end := StartWorkflowSpan(ctx, "step")
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+		err := workflow.ExecuteActivity(ctx, "TestActivity").Get(ctx, nil)
+		end(err)
+		return err
+	}, workflow.RegisterOptions{Name: "TestWorkflow"})
+
+	env.ExecuteWorkflow("TestWorkflow")
+	suite.Require().True(env.IsWorkflowCompleted())
+	suite.Require().NoError(env.GetWorkflowError())
+
+	// Check the step span is between the workflow and the activity
+	spans := suite.spans()
+	suite.Require().Contains(spans, "step")
+	suite.Require().Contains(spans, "StartActivity:TestActivity")
+	suite.Require().Equal(spans["step"].SpanContext().SpanID(),
+		spans["StartActivity:TestActivity"].Parent().SpanID())
+	suite.Require().Equal(spans["step"].SpanContext().TraceID(),
+		spans["RunActivity:TestActivity"].SpanContext().TraceID())
+	suite.Require().Equal(spans["RunWorkflow:TestWorkflow"].SpanContext().SpanID(),
+		spans["step"].Parent().SpanID())
+
+	// Check the step starts at the deterministic workflow time
+	suite.Require().True(spans["step"].StartTime().Equal(time.Unix(60, 0)), spans["step"].StartTime())
+}
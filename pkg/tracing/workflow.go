@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/sdk/contrib/opentelemetry"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/workflow"
+)
+
+// workflowTracer converts the spans of the workflow context from and to the
+// ones of the Temporal interceptor. It does not create any span.
+var workflowTracer, _ = opentelemetry.NewTracer(opentelemetry.TracerOptions{
+	SpanContextKey: spanContextKey{},
+})
+
+// StartWorkflowSpan starts a span as a child of the workflow span, and returns
+// the workflow context carrying it along with the function ending it. Nothing
+// is created when the workflow is not traced (i.e. the interceptor is not set).
+//
+// The span starts at the deterministic workflow time and is started again when
+// the workflow is replayed, like the workflow span itself: if the worker evicts
+// the workflow while the step is blocked, the replay ends the span instead of
+// losing it. It is only ended when not replaying, so each step is only
+// exported once.
+func StartWorkflowSpan(ctx workflow.Context, name string) (workflow.Context, func(err error)) {
+	parent, ok := ctx.Value(spanContextKey{}).(interceptor.TracerSpan)
+	if !ok {
+		return ctx, func(error) {}
+	}
+
+	spanCtx := workflowTracer.ContextWithSpan(context.Background(), parent)
+	spanCtx, s := trace.SpanFromContext(spanCtx).TracerProvider().Tracer(InstrumentationName).Start(
+		spanCtx, name, trace.WithTimestamp(workflow.Now(ctx)))
+
+	return workflow.WithValue(ctx, spanContextKey{}, workflowTracer.SpanFromContext(spanCtx)), func(err error) {
+		// Already ended by the execution that has been replayed
+		if workflow.IsReplaying(ctx) {
+			return
+		}
+		end(s, err)
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/cryptellation/candlesticks/pkg/candlestick"
 	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/sma"
 	timeserie "github.com/cryptellation/timeseries"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/worker"
@@ -28,6 +30,8 @@ type (
 		PriceType    candlestick.PriceType
 		Start        time.Time
 		End          time.Time
+		// MinAlgorithmVersion excludes points computed with an older algorithm version.
+		MinAlgorithmVersion int
 	}
 
 	// ReadSMAActivityResults is the result for the GetSMA activity.
@@ -48,16 +52,141 @@ type (
 		PeriodNumber int
 		PriceType    candlestick.PriceType
 		TimeSerie    *timeserie.TimeSerie[float64]
+		// AlgorithmVersion is the version of the algorithm used to compute the points.
+		AlgorithmVersion int
+		// ComputedAt is the time when the points have been computed.
+		ComputedAt time.Time
 	}
 
 	// UpsertSMAActivityResults is the result for the UpsertSMA activity.
 	UpsertSMAActivityResults struct{}
 )
 
+// InvalidateSMAActivityName is the name of the InvalidateSMA activity.
+const InvalidateSMAActivityName = "InvalidateSMAActivity"
+
+type (
+	// InvalidateSMAActivityParams is the parameters for the InvalidateSMA activity.
+	// Start and End delimit the corrected candlesticks: every point whose
+	// window contains one of them is removed, whatever its period number and
+	// price type.
+	InvalidateSMAActivityParams struct {
+		Exchange string
+		Pair     string
+		Period   period.Symbol
+		Start    time.Time
+		End      time.Time
+	}
+
+	// InvalidateSMAActivityResults is the result for the InvalidateSMA activity.
+	InvalidateSMAActivityResults struct {
+		InvalidatedCount int
+	}
+)
+
+// ListSeriesActivityName is the name of the ListSeries activity.
+const ListSeriesActivityName = "ListSeriesActivity"
+
+type (
+	// ListSeriesActivityParams is the parameters for the ListSeries activity.
+	ListSeriesActivityParams struct{}
+
+	// SeriesInfo describes a stored SMA series.
+	SeriesInfo struct {
+		Exchange     string
+		Pair         string
+		Period       period.Symbol
+		PeriodNumber int
+		PriceType    candlestick.PriceType
+		First        time.Time
+		Last         time.Time
+		Count        int
+		// GapCount is the number of holes between the first and last points.
+		GapCount int
+	}
+
+	// ListSeriesActivityResults is the result for the ListSeries activity.
+	ListSeriesActivityResults struct {
+		Series []SeriesInfo
+	}
+)
+
+// GapReportActivityName is the name of the GapReport activity.
+const GapReportActivityName = "GapReportActivity"
+
+type (
+	// GapReportActivityParams is the parameters for the GapReport activity.
+	GapReportActivityParams struct {
+		Exchange     string
+		Pair         string
+		Period       period.Symbol
+		PeriodNumber int
+		PriceType    candlestick.PriceType
+		Start        time.Time
+		End          time.Time
+	}
+
+	// GapReportActivityResults is the result for the GapReport activity.
+	GapReportActivityResults struct {
+		// Missing are the time ranges without any up to date stored point.
+		Missing []timeserie.TimeRange
+		// Invalid are the time ranges with stored points having an invalid value.
+		Invalid []timeserie.TimeRange
+	}
+)
+
+// ExportActivityName is the name of the Export activity.
+const ExportActivityName = "ExportActivity"
+
+type (
+	// ExportActivityParams is the parameters for the Export activity.
+	// Empty filters and zero times select every stored point.
+	ExportActivityParams struct {
+		Exchange     string
+		Pair         string
+		Period       period.Symbol
+		PeriodNumber int
+		PriceType    candlestick.PriceType
+		Start        time.Time
+		End          time.Time
+		Format       api.ExportFormat
+		// Path is the path of the created file.
+		Path string
+		// Overwrite replaces the file if it already exists.
+		Overwrite bool
+	}
+
+	// ExportActivityResults is the result for the Export activity.
+	ExportActivityResults struct {
+		Count int
+	}
+)
+
+// Match returns true if the point of the series at the given time is selected
+// by the parameters.
+func (p ExportActivityParams) Match(
+	exchange, pair string,
+	per period.Symbol,
+	periodNumber int,
+	priceType candlestick.PriceType,
+	t time.Time,
+) bool {
+	return (p.Exchange == "" || p.Exchange == exchange) &&
+		(p.Pair == "" || p.Pair == pair) &&
+		(p.Period == "" || p.Period == per) &&
+		(p.PeriodNumber == 0 || p.PeriodNumber == periodNumber) &&
+		(p.PriceType == "" || p.PriceType == priceType) &&
+		(p.Start.IsZero() || !t.Before(p.Start)) &&
+		(p.End.IsZero() || !t.After(p.End))
+}
+
 // DB is the interface for the database activities.
 type DB interface {
 	Register(w worker.Worker)
 
+	// Ping checks that the database is reachable.
+	Ping(ctx context.Context) error
+
 	ReadSMAActivity(
 		ctx context.Context,
 		params ReadSMAActivityParams,
@@ -67,6 +196,26 @@ type DB interface {
 		ctx context.Context,
 		params UpsertSMAActivityParams,
 	) (UpsertSMAActivityResults, error)
+
+	InvalidateSMAActivity(
+		ctx context.Context,
+		params InvalidateSMAActivityParams,
+	) (InvalidateSMAActivityResults, error)
+
+	ListSeriesActivity(
+		ctx context.Context,
+		params ListSeriesActivityParams,
+	) (ListSeriesActivityResults, error)
+
+	GapReportActivity(
+		ctx context.Context,
+		params GapReportActivityParams,
+	) (GapReportActivityResults, error)
+
+	ExportActivity(
+		ctx context.Context,
+		params ExportActivityParams,
+	) (ExportActivityResults, error)
 }
 
 // DefaultActivityOptions returns the default database activities options.
@@ -82,3 +231,25 @@ func DefaultActivityOptions() workflow.ActivityOptions {
 		ScheduleToCloseTimeout: 10 * time.Second,
 	}
 }
+
+// ExportActivityOptions returns the options of the export activity, that can
+// run for a long time on large series.
+func ExportActivityOptions() workflow.ActivityOptions {
+	opts := DefaultActivityOptions()
+	opts.StartToCloseTimeout = time.Hour
+	opts.ScheduleToCloseTimeout = time.Hour
+	opts.HeartbeatTimeout = time.Minute
+	return opts
+}
+
+// NewGapReport creates the gap report of a stored SMA series on the given range.
+func NewGapReport(
+	ts *timeserie.TimeSerie[float64],
+	params GapReportActivityParams,
+) GapReportActivityResults {
+	interval := params.Period.Duration()
+	return GapReportActivityResults{
+		Missing: ts.GetMissingRanges(params.Start, params.End, interval, 0),
+		Invalid: sma.InvalidRanges(ts, interval),
+	}
+}
@@ -0,0 +1,339 @@
+package mem
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/pkg/export"
+	"github.com/cryptellation/sma/pkg/sma"
+	"github.com/cryptellation/sma/svc/db"
+	"github.com/cryptellation/timeseries"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/worker"
+)
+
+var _ db.DB = (*Activities)(nil)
+
+// serieKey identifies a SMA serie, the same way the SQL primary key does
+// (without the time).
+type serieKey struct {
+	Exchange     string
+	Pair         string
+	Period       period.Symbol
+	PeriodNumber int
+	PriceType    candlestick.PriceType
+}
+
+// info returns the series information corresponding to the key.
+func (k serieKey) info() db.SeriesInfo {
+	return db.SeriesInfo{
+		Exchange:     k.Exchange,
+		Pair:         k.Pair,
+		Period:       k.Period,
+		PeriodNumber: k.PeriodNumber,
+		PriceType:    k.PriceType,
+	}
+}
+
+// point is a stored SMA point with its computation metadata.
+type point struct {
+	Value            float64
+	AlgorithmVersion int
+	ComputedAt       time.Time
+}
+
+// Activities is an in-memory implementation of the database activities.
+// It is safe for concurrent use and is intended for tests and ephemeral workers.
+type Activities struct {
+	mutex  sync.RWMutex
+	series map[serieKey]*timeseries.TimeSerie[point]
+}
+
+// New creates a new in-memory activities.
+func New() *Activities {
+	return &Activities{
+		series: make(map[serieKey]*timeseries.TimeSerie[point]),
+	}
+}
+
+// Register registers the activities.
+func (a *Activities) Register(w worker.Worker) {
+	w.RegisterActivityWithOptions(
+		a.ReadSMAActivity,
+		activity.RegisterOptions{Name: db.ReadSMAActivityName},
+	)
+	w.RegisterActivityWithOptions(
+		a.UpsertSMAActivity,
+		activity.RegisterOptions{Name: db.UpsertSMAActivityName},
+	)
+	w.RegisterActivityWithOptions(
+		a.InvalidateSMAActivity,
+		activity.RegisterOptions{Name: db.InvalidateSMAActivityName},
+	)
+	w.RegisterActivityWithOptions(
+		a.ListSeriesActivity,
+		activity.RegisterOptions{Name: db.ListSeriesActivityName},
+	)
+	w.RegisterActivityWithOptions(
+		a.GapReportActivity,
+		activity.RegisterOptions{Name: db.GapReportActivityName},
+	)
+	w.RegisterActivityWithOptions(
+		a.ExportActivity,
+		activity.RegisterOptions{Name: db.ExportActivityName},
+	)
+}
+
+// Ping checks that the database is reachable, which is always the case in memory.
+func (a *Activities) Ping(_ context.Context) error {
+	return nil
+}
+
+// Reset will reset the database.
+func (a *Activities) Reset(_ context.Context) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.series = make(map[serieKey]*timeseries.TimeSerie[point])
+	return nil
+}
+
+// ReadSMAActivity reads the SMA points from memory.
+func (a *Activities) ReadSMAActivity(
+	_ context.Context,
+	params db.ReadSMAActivityParams,
+) (db.ReadSMAActivityResults, error) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	// Get the serie
+	ts, ok := a.series[serieKey{
+		Exchange:     params.Exchange,
+		Pair:         params.Pair,
+		Period:       params.Period,
+		PeriodNumber: params.PeriodNumber,
+		PriceType:    params.PriceType,
+	}]
+	if !ok {
+		return db.ReadSMAActivityResults{
+			Data: timeseries.New[float64](),
+		}, nil
+	}
+
+	// Copy the requested range
+	data := timeseries.New[float64]()
+	err := ts.Extract(params.Start.UTC(), params.End.UTC(), 0).Loop(func(t time.Time, p point) (bool, error) {
+		if p.AlgorithmVersion >= params.MinAlgorithmVersion {
+			data.Set(t, p.Value)
+		}
+		return false, nil
+	})
+
+	return db.ReadSMAActivityResults{
+		Data: data,
+	}, err
+}
+
+// UpsertSMAActivity upserts the SMA points in memory.
+func (a *Activities) UpsertSMAActivity(
+	_ context.Context,
+	params db.UpsertSMAActivityParams,
+) (db.UpsertSMAActivityResults, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	// Get or create the serie
+	key := serieKey{
+		Exchange:     params.Exchange,
+		Pair:         params.Pair,
+		Period:       params.Period,
+		PeriodNumber: params.PeriodNumber,
+		PriceType:    params.PriceType,
+	}
+	ts, ok := a.series[key]
+	if !ok {
+		ts = timeseries.New[point]()
+		a.series[key] = ts
+	}
+
+	// Upsert the points
+	if params.TimeSerie == nil {
+		return db.UpsertSMAActivityResults{}, nil
+	}
+	err := params.TimeSerie.Loop(func(t time.Time, v float64) (bool, error) {
+		ts.Set(t.UTC(), point{
+			Value:            v,
+			AlgorithmVersion: params.AlgorithmVersion,
+			ComputedAt:       params.ComputedAt.UTC(),
+		})
+		return false, nil
+	})
+
+	return db.UpsertSMAActivityResults{}, err
+}
+
+// InvalidateSMAActivity removes the SMA points computed from candlesticks in
+// the given range from memory.
+func (a *Activities) InvalidateSMAActivity(
+	_ context.Context,
+	params db.InvalidateSMAActivityParams,
+) (db.InvalidateSMAActivityResults, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	count := 0
+	for key, ts := range a.series {
+		if key.Exchange != params.Exchange || key.Pair != params.Pair || key.Period != params.Period {
+			continue
+		}
+
+		// A point at time T with N periods uses candlesticks from T-(N-1)*period to T,
+		// so the affected points go up to N-1 periods after the end of the range.
+		end := params.End.Add(params.Period.Duration() * time.Duration(key.PeriodNumber-1))
+		toDelete := make([]time.Time, 0)
+		_ = ts.Extract(params.Start.UTC(), end.UTC(), 0).Loop(func(t time.Time, _ point) (bool, error) {
+			toDelete = append(toDelete, t)
+			return false, nil
+		})
+
+		ts.Delete(toDelete...)
+		count += len(toDelete)
+	}
+
+	return db.InvalidateSMAActivityResults{
+		InvalidatedCount: count,
+	}, nil
+}
+
+// ListSeriesActivity lists the SMA series stored in memory.
+func (a *Activities) ListSeriesActivity(
+	_ context.Context,
+	_ db.ListSeriesActivityParams,
+) (db.ListSeriesActivityResults, error) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	series := make([]db.SeriesInfo, 0, len(a.series))
+	for key, ts := range a.series {
+		if ts.Len() == 0 {
+			continue
+		}
+
+		// Get boundaries and count the gaps between them
+		info := db.SeriesInfo{
+			Exchange:     key.Exchange,
+			Pair:         key.Pair,
+			Period:       key.Period,
+			PeriodNumber: key.PeriodNumber,
+			PriceType:    key.PriceType,
+			Count:        ts.Len(),
+		}
+		info.First, _, _ = ts.First()
+		info.Last, _, _ = ts.Last()
+		info.GapCount = len(ts.GetMissingRanges(info.First, info.Last, key.Period.Duration(), 0))
+
+		series = append(series, info)
+	}
+
+	// Sort the series the same way the SQL database does
+	sort.Slice(series, func(i, j int) bool {
+		return seriesKeyLess(series[i], series[j])
+	})
+
+	return db.ListSeriesActivityResults{
+		Series: series,
+	}, nil
+}
+
+// GapReportActivity reports the missing and invalid SMA points of a series
+// stored in the memory on the given range. Points computed with an older
+// algorithm version are reported as missing.
+func (a *Activities) GapReportActivity(
+	ctx context.Context,
+	params db.GapReportActivityParams,
+) (db.GapReportActivityResults, error) {
+	// Read the stored points
+	res, err := a.ReadSMAActivity(ctx, db.ReadSMAActivityParams{
+		Exchange:            params.Exchange,
+		Pair:                params.Pair,
+		Period:              params.Period,
+		PeriodNumber:        params.PeriodNumber,
+		PriceType:           params.PriceType,
+		Start:               params.Start,
+		End:                 params.End,
+		MinAlgorithmVersion: sma.AlgorithmVersion,
+	})
+	if err != nil {
+		return db.GapReportActivityResults{}, err
+	}
+
+	return db.NewGapReport(res.Data, params), nil
+}
+
+// ExportActivity writes the selected SMA points stored in the memory to a file.
+func (a *Activities) ExportActivity(
+	ctx context.Context,
+	params db.ExportActivityParams,
+) (db.ExportActivityResults, error) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	// Sort the series the same way the SQL database does
+	keys := make([]serieKey, 0, len(a.series))
+	for key := range a.series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return seriesKeyLess(keys[i].info(), keys[j].info())
+	})
+
+	file := export.File{Path: params.Path, Format: params.Format, Overwrite: params.Overwrite}
+	count, err := file.Write(ctx, func(write func(export.Row) error) error {
+		for _, key := range keys {
+			err := a.series[key].Loop(func(t time.Time, p point) (bool, error) {
+				if !params.Match(key.Exchange, key.Pair, key.Period, key.PeriodNumber, key.PriceType, t) {
+					return false, nil
+				}
+
+				return false, write(export.Row{
+					Exchange:     key.Exchange,
+					Pair:         key.Pair,
+					Period:       key.Period,
+					PeriodNumber: key.PeriodNumber,
+					PriceType:    key.PriceType,
+					Time:         t,
+					Value:        p.Value,
+				})
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return db.ExportActivityResults{}, err
+	}
+
+	return db.ExportActivityResults{Count: count}, nil
+}
+
+func seriesKeyLess(a, b db.SeriesInfo) bool {
+	if a.Exchange != b.Exchange {
+		return a.Exchange < b.Exchange
+	}
+	if a.Pair != b.Pair {
+		return a.Pair < b.Pair
+	}
+	if a.Period != b.Period {
+		return a.Period < b.Period
+	}
+	if a.PeriodNumber != b.PeriodNumber {
+		return a.PeriodNumber < b.PeriodNumber
+	}
+	return a.PriceType < b.PriceType
+}
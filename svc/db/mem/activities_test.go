@@ -0,0 +1,29 @@
+//go:build unit
+// +build unit
+
+package mem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cryptellation/sma/svc/db"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestIndicatorsSuite(t *testing.T) {
+	suite.Run(t, new(IndicatorsSuite))
+}
+
+type IndicatorsSuite struct {
+	db.IndicatorsSuite
+}
+
+func (suite *IndicatorsSuite) SetupSuite() {
+	suite.DB = New()
+}
+
+func (suite *IndicatorsSuite) SetupTest() {
+	db := suite.DB.(*Activities)
+	suite.Require().NoError(db.Reset(context.Background()))
+}
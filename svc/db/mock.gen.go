@@ -35,6 +35,80 @@ func (m *MockDB) EXPECT() *MockDBMockRecorder {
 	return m.recorder
 }
 
+// ExportActivity mocks base method.
+func (m *MockDB) ExportActivity(ctx context.Context, params ExportActivityParams) (ExportActivityResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportActivity", ctx, params)
+	ret0, _ := ret[0].(ExportActivityResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportActivity indicates an expected call of ExportActivity.
+func (mr *MockDBMockRecorder) ExportActivity(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportActivity", reflect.TypeOf((*MockDB)(nil).ExportActivity), ctx, params)
+}
+
+// GapReportActivity mocks base method.
+func (m *MockDB) GapReportActivity(ctx context.Context, params GapReportActivityParams) (GapReportActivityResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GapReportActivity", ctx, params)
+	ret0, _ := ret[0].(GapReportActivityResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GapReportActivity indicates an expected call of GapReportActivity.
+func (mr *MockDBMockRecorder) GapReportActivity(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GapReportActivity", reflect.TypeOf((*MockDB)(nil).GapReportActivity), ctx, params)
+}
+
+// InvalidateSMAActivity mocks base method.
+func (m *MockDB) InvalidateSMAActivity(ctx context.Context, params InvalidateSMAActivityParams) (InvalidateSMAActivityResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateSMAActivity", ctx, params)
+	ret0, _ := ret[0].(InvalidateSMAActivityResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvalidateSMAActivity indicates an expected call of InvalidateSMAActivity.
+func (mr *MockDBMockRecorder) InvalidateSMAActivity(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateSMAActivity", reflect.TypeOf((*MockDB)(nil).InvalidateSMAActivity), ctx, params)
+}
+
+// ListSeriesActivity mocks base method.
+func (m *MockDB) ListSeriesActivity(ctx context.Context, params ListSeriesActivityParams) (ListSeriesActivityResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeriesActivity", ctx, params)
+	ret0, _ := ret[0].(ListSeriesActivityResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeriesActivity indicates an expected call of ListSeriesActivity.
+func (mr *MockDBMockRecorder) ListSeriesActivity(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeriesActivity", reflect.TypeOf((*MockDB)(nil).ListSeriesActivity), ctx, params)
+}
+
+// Ping mocks base method.
+func (m *MockDB) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockDBMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockDB)(nil).Ping), ctx)
+}
+
 // ReadSMAActivity mocks base method.
 func (m *MockDB) ReadSMAActivity(ctx context.Context, params ReadSMAActivityParams) (ReadSMAActivityResults, error) {
 	m.ctrl.T.Helper()
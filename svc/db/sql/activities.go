@@ -3,11 +3,19 @@ package sql
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/XSAM/otelsql"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/pkg/export"
+	"github.com/cryptellation/sma/pkg/logging"
+	"github.com/cryptellation/sma/pkg/metrics"
+	"github.com/cryptellation/sma/pkg/sma"
 	"github.com/cryptellation/sma/svc/db"
 	"github.com/cryptellation/sma/svc/db/sql/entities"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq" // PostGres driver
+	"github.com/lib/pq" // PostGres driver
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/worker"
 )
@@ -22,18 +30,28 @@ type Activities struct {
 
 // New creates a new activities.
 func New(ctx context.Context, dsn string) (*Activities, error) {
-	// Create embedded database access
-	db, err := sqlx.ConnectContext(ctx, "postgres", dsn)
+	// Create embedded database access, tracing the queries with the span of
+	// the activity from the context
+	sqlDB, err := otelsql.Open("postgres", dsn,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
 		return nil, err
 	}
 
-	// Create a structure
-	a := &Activities{
-		db: db,
+	db := sqlx.NewDb(sqlDB, "postgres")
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
 	}
 
-	return a, nil
+	return NewFromDB(db), nil
+}
+
+// NewFromDB creates a new activities from an existing database connection.
+func NewFromDB(db *sqlx.DB) *Activities {
+	return &Activities{
+		db: db,
+	}
 }
 
 // Register registers the activities.
@@ -46,6 +64,27 @@ func (a *Activities) Register(w worker.Worker) {
 		a.UpsertSMAActivity,
 		activity.RegisterOptions{Name: db.UpsertSMAActivityName},
 	)
+	w.RegisterActivityWithOptions(
+		a.InvalidateSMAActivity,
+		activity.RegisterOptions{Name: db.InvalidateSMAActivityName},
+	)
+	w.RegisterActivityWithOptions(
+		a.ListSeriesActivity,
+		activity.RegisterOptions{Name: db.ListSeriesActivityName},
+	)
+	w.RegisterActivityWithOptions(
+		a.GapReportActivity,
+		activity.RegisterOptions{Name: db.GapReportActivityName},
+	)
+	w.RegisterActivityWithOptions(
+		a.ExportActivity,
+		activity.RegisterOptions{Name: db.ExportActivityName},
+	)
+}
+
+// Ping checks that the database is reachable.
+func (a *Activities) Ping(ctx context.Context) error {
+	return a.db.PingContext(ctx)
 }
 
 // Reset will reset the database.
@@ -63,7 +102,38 @@ func (a *Activities) ReadSMAActivity(
 	ctx context.Context,
 	params db.ReadSMAActivityParams,
 ) (db.ReadSMAActivityResults, error) {
+	logger := logging.SeriesLogger(ctx,
+		params.Exchange, params.Pair, params.Period, params.PeriodNumber, params.PriceType)
+
 	// Query the SMA points
+	start := time.Now()
+	results, err := a.readSMA(ctx, params)
+	metrics.RecordQueryLatency(ctx, db.ReadSMAActivityName, start)
+	if err != nil {
+		return db.ReadSMAActivityResults{}, err
+	}
+
+	// To model list
+	data, err := entities.FromEntityListToModelList(results)
+	if err != nil {
+		return db.ReadSMAActivityResults{}, fmt.Errorf("from entity list to model list: %w", err)
+	}
+
+	// Return the results
+	logger.Debug("Read SMA points from database",
+		"start", params.Start,
+		"end", params.End,
+		"count", data.Len())
+	return db.ReadSMAActivityResults{
+		Data: data,
+	}, nil
+}
+
+// readSMA queries the SMA points from the database.
+func (a *Activities) readSMA(
+	ctx context.Context,
+	params db.ReadSMAActivityParams,
+) ([]entities.SimpleMovingAverage, error) {
 	rows, err := a.db.QueryxContext(
 		ctx,
 		`SELECT *
@@ -73,7 +143,8 @@ func (a *Activities) ReadSMAActivity(
 			period = $3 AND 
 			period_number = $4 AND
 			price_type = $5 AND
-			time >= $6 AND time <= $7
+			time >= $6 AND time <= $7 AND
+			algorithm_version >= $8
 		ORDER BY time ASC`,
 		params.Exchange,
 		params.Pair,
@@ -82,9 +153,10 @@ func (a *Activities) ReadSMAActivity(
 		params.PriceType,
 		params.Start.UTC(),
 		params.End.UTC(),
+		params.MinAlgorithmVersion,
 	)
 	if err != nil {
-		return db.ReadSMAActivityResults{}, fmt.Errorf("querying SMA points: %w", err)
+		return nil, fmt.Errorf("querying SMA points: %w", err)
 	}
 	defer rows.Close()
 
@@ -95,23 +167,17 @@ func (a *Activities) ReadSMAActivity(
 		var point entities.SimpleMovingAverage
 		err = rows.StructScan(&point)
 		if err != nil {
-			return db.ReadSMAActivityResults{}, fmt.Errorf("scanning SMA point: %w", err)
+			return nil, fmt.Errorf("scanning SMA point: %w", err)
 		}
 
 		// Append the point
 		results = append(results, point)
 	}
-
-	// To model list
-	data, err := entities.FromEntityListToModelList(results)
-	if err != nil {
-		return db.ReadSMAActivityResults{}, fmt.Errorf("from entity list to model list: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading SMA points: %w", err)
 	}
 
-	// Return the results
-	return db.ReadSMAActivityResults{
-		Data: data,
-	}, nil
+	return results, nil
 }
 
 // UpsertSMAActivity upserts the SMA points in the database.
@@ -119,6 +185,9 @@ func (a *Activities) UpsertSMAActivity(
 	ctx context.Context,
 	params db.UpsertSMAActivityParams,
 ) (db.UpsertSMAActivityResults, error) {
+	logger := logging.SeriesLogger(ctx,
+		params.Exchange, params.Pair, params.Period, params.PeriodNumber, params.PriceType)
+
 	// Create entities
 	ents, err := entities.FromModelListToEntityList(
 		params.Exchange,
@@ -126,23 +195,266 @@ func (a *Activities) UpsertSMAActivity(
 		params.Period,
 		params.PeriodNumber,
 		params.PriceType,
-		params.TimeSerie)
+		params.TimeSerie,
+		params.AlgorithmVersion,
+		params.ComputedAt)
 	if err != nil {
 		return db.UpsertSMAActivityResults{}, fmt.Errorf("from model list to entity list: %w", err)
 	}
 
 	// Bulk insert the SMA
+	start := time.Now()
 	_, err = a.db.NamedExecContext(
 		ctx,
-		`INSERT INTO sma (exchange, pair, period, period_number, price_type, time, data)
-		VALUES (:exchange, :pair, :period, :period_number, :price_type, :time, :data)
+		`INSERT INTO sma (exchange, pair, period, period_number, price_type, time, data,
+			algorithm_version, computed_at)
+		VALUES (:exchange, :pair, :period, :period_number, :price_type, :time, :data,
+			:algorithm_version, :computed_at)
 		ON CONFLICT (exchange, pair, period, period_number, price_type, time) DO UPDATE
-		SET data = EXCLUDED.data`,
+		SET data = EXCLUDED.data,
+			algorithm_version = EXCLUDED.algorithm_version,
+			computed_at = EXCLUDED.computed_at`,
 		entities.FromEntitiesToMap(ents),
 	)
+	metrics.RecordQueryLatency(ctx, db.UpsertSMAActivityName, start)
 	if err != nil {
 		return db.UpsertSMAActivityResults{}, fmt.Errorf("bulk inserting sma: %w", err)
 	}
 
+	logger.Debug("Upserted SMA points in database",
+		"count", len(ents))
 	return db.UpsertSMAActivityResults{}, nil
 }
+
+// InvalidateSMAActivity removes the SMA points computed from candlesticks in
+// the given range from the database.
+func (a *Activities) InvalidateSMAActivity(
+	ctx context.Context,
+	params db.InvalidateSMAActivityParams,
+) (db.InvalidateSMAActivityResults, error) {
+	// A point at time T with N periods uses candlesticks from T-(N-1)*period to T,
+	// so the affected points go up to N-1 periods after the end of the range.
+	start := time.Now()
+	res, err := a.db.ExecContext(
+		ctx,
+		`DELETE FROM sma
+		WHERE exchange = $1 AND
+			pair = $2 AND
+			period = $3 AND
+			time >= $4::timestamp AND
+			time <= $5::timestamp + (period_number - 1) * $6::integer * INTERVAL '1 second'`,
+		params.Exchange,
+		params.Pair,
+		params.Period,
+		params.Start.UTC(),
+		params.End.UTC(),
+		int(params.Period.Duration().Seconds()),
+	)
+	metrics.RecordQueryLatency(ctx, db.InvalidateSMAActivityName, start)
+	if err != nil {
+		return db.InvalidateSMAActivityResults{}, fmt.Errorf("deleting sma rows: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return db.InvalidateSMAActivityResults{}, fmt.Errorf("getting deleted sma rows count: %w", err)
+	}
+
+	return db.InvalidateSMAActivityResults{
+		InvalidatedCount: int(count),
+	}, nil
+}
+
+// ListSeriesActivity lists the SMA series stored in the database.
+func (a *Activities) ListSeriesActivity(
+	ctx context.Context,
+	_ db.ListSeriesActivityParams,
+) (db.ListSeriesActivityResults, error) {
+	// Set periods durations to detect gaps
+	symbols := period.Symbols()
+	periods := make([]string, 0, len(symbols))
+	durations := make([]int64, 0, len(symbols))
+	for _, s := range symbols {
+		periods = append(periods, s.String())
+		durations = append(durations, int64(s.Duration().Seconds()))
+	}
+
+	// Query the series
+	ents := make([]entities.SeriesInfo, 0)
+	start := time.Now()
+	err := a.db.SelectContext(
+		ctx,
+		&ents,
+		`WITH periods AS (
+			SELECT * FROM unnest($1::text[], $2::bigint[]) AS p(period, seconds)
+		), points AS (
+			SELECT exchange, pair, period, period_number, price_type, time,
+				EXTRACT(EPOCH FROM time - LAG(time) OVER (
+					PARTITION BY exchange, pair, period, period_number, price_type
+					ORDER BY time ASC
+				)) AS diff
+			FROM sma
+		)
+		SELECT points.exchange, points.pair, points.period, points.period_number, points.price_type,
+			MIN(points.time) AS first,
+			MAX(points.time) AS last,
+			COUNT(*) AS count,
+			COUNT(*) FILTER (WHERE points.diff > periods.seconds) AS gap_count
+		FROM points
+		JOIN periods ON periods.period = points.period
+		GROUP BY points.exchange, points.pair, points.period, points.period_number, points.price_type
+		ORDER BY points.exchange, points.pair, points.period, points.period_number, points.price_type`,
+		pq.Array(periods),
+		pq.Array(durations),
+	)
+	metrics.RecordQueryLatency(ctx, db.ListSeriesActivityName, start)
+	if err != nil {
+		return db.ListSeriesActivityResults{}, fmt.Errorf("listing sma series: %w", err)
+	}
+
+	// To model list
+	series := make([]db.SeriesInfo, 0, len(ents))
+	for _, e := range ents {
+		s, err := e.ToModel()
+		if err != nil {
+			return db.ListSeriesActivityResults{}, fmt.Errorf("from entity to model: %w", err)
+		}
+		series = append(series, s)
+	}
+
+	return db.ListSeriesActivityResults{
+		Series: series,
+	}, nil
+}
+
+// GapReportActivity reports the missing and invalid SMA points of a series
+// stored in the database on the given range. Points computed with an older
+// algorithm version are reported as missing.
+func (a *Activities) GapReportActivity(
+	ctx context.Context,
+	params db.GapReportActivityParams,
+) (db.GapReportActivityResults, error) {
+	// Read the stored points
+	start := time.Now()
+	ents, err := a.readSMA(ctx, db.ReadSMAActivityParams{
+		Exchange:            params.Exchange,
+		Pair:                params.Pair,
+		Period:              params.Period,
+		PeriodNumber:        params.PeriodNumber,
+		PriceType:           params.PriceType,
+		Start:               params.Start,
+		End:                 params.End,
+		MinAlgorithmVersion: sma.AlgorithmVersion,
+	})
+	metrics.RecordQueryLatency(ctx, db.GapReportActivityName, start)
+	if err != nil {
+		return db.GapReportActivityResults{}, err
+	}
+
+	data, err := entities.FromEntityListToModelList(ents)
+	if err != nil {
+		return db.GapReportActivityResults{}, fmt.Errorf("from entity list to model list: %w", err)
+	}
+
+	return db.NewGapReport(data, params), nil
+}
+
+// ExportActivity writes the selected SMA points stored in the database to a
+// file. Points are streamed from the database so that series larger than the
+// memory can be exported.
+func (a *Activities) ExportActivity(
+	ctx context.Context,
+	params db.ExportActivityParams,
+) (db.ExportActivityResults, error) {
+	// Use unbounded times when not set
+	start, end := params.Start, params.End
+	if start.IsZero() {
+		start = time.Unix(0, 0)
+	}
+	if end.IsZero() {
+		end = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Only the query is recorded, as the rows are then streamed to the file
+	queryStart := time.Now()
+	rows, err := a.db.QueryxContext(ctx,
+		`SELECT * FROM sma
+		WHERE ($1 = '' OR exchange = $1) AND
+			($2 = '' OR pair = $2) AND
+			($3 = '' OR period = $3) AND
+			($4 = 0 OR period_number = $4) AND
+			($5 = '' OR price_type = $5) AND
+			time BETWEEN $6 AND $7
+		ORDER BY exchange, pair, period, period_number, price_type, time`,
+		params.Exchange,
+		params.Pair,
+		params.Period.String(),
+		params.PeriodNumber,
+		params.PriceType.String(),
+		start.UTC(),
+		end.UTC())
+	metrics.RecordQueryLatency(ctx, db.ExportActivityName, queryStart)
+	if err != nil {
+		return db.ExportActivityResults{}, fmt.Errorf("selecting sma rows: %w", err)
+	}
+	defer rows.Close()
+
+	file := export.File{Path: params.Path, Format: params.Format, Overwrite: params.Overwrite}
+	count, err := file.Write(ctx, func(write func(export.Row) error) error {
+		for rows.Next() {
+			var e entities.SimpleMovingAverage
+			if err := rows.StructScan(&e); err != nil {
+				return fmt.Errorf("scanning sma row: %w", err)
+			}
+
+			p, err := e.ToModel()
+			if err != nil {
+				return fmt.Errorf("from entity to model: %w", err)
+			}
+
+			err = write(export.Row{
+				Exchange:     p.Exchange,
+				Pair:         p.Pair,
+				Period:       p.Period,
+				PeriodNumber: p.PeriodNb,
+				PriceType:    p.PriceType,
+				Time:         p.Time,
+				Value:        p.Price,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return db.ExportActivityResults{}, err
+	}
+
+	return db.ExportActivityResults{Count: count}, nil
+}
+
+// AlgorithmVersionCount is the count of SMA points computed with a given
+// algorithm version.
+type AlgorithmVersionCount struct {
+	AlgorithmVersion int `db:"algorithm_version"`
+	Count            int `db:"count"`
+}
+
+// CountPointsPerAlgorithmVersion returns the count of SMA points per algorithm version.
+func (a *Activities) CountPointsPerAlgorithmVersion(ctx context.Context) ([]AlgorithmVersionCount, error) {
+	counts := make([]AlgorithmVersionCount, 0)
+	err := a.db.SelectContext(
+		ctx,
+		&counts,
+		`SELECT algorithm_version, COUNT(*) AS count
+		FROM sma
+		GROUP BY algorithm_version
+		ORDER BY algorithm_version ASC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("counting sma points per algorithm version: %w", err)
+	}
+
+	return counts, nil
+}
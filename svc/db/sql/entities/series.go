@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/svc/db"
+)
+
+// SeriesInfo is the entity for the information on a stored SMA series.
+type SeriesInfo struct {
+	Exchange     string    `db:"exchange"`
+	Pair         string    `db:"pair"`
+	Period       string    `db:"period"`
+	PeriodNumber int       `db:"period_number"`
+	PriceType    string    `db:"price_type"`
+	First        time.Time `db:"first"`
+	Last         time.Time `db:"last"`
+	Count        int       `db:"count"`
+	GapCount     int       `db:"gap_count"`
+}
+
+// ToModel converts the entity to a model.
+func (s SeriesInfo) ToModel() (db.SeriesInfo, error) {
+	// Validate period
+	per := period.Symbol(s.Period)
+	if err := per.Validate(); err != nil {
+		return db.SeriesInfo{}, err
+	}
+
+	// Validate price type
+	pt := candlestick.PriceType(s.PriceType)
+	if err := pt.Validate(); err != nil {
+		return db.SeriesInfo{}, err
+	}
+
+	return db.SeriesInfo{
+		Exchange:     s.Exchange,
+		Pair:         s.Pair,
+		Period:       per,
+		PeriodNumber: s.PeriodNumber,
+		PriceType:    pt,
+		First:        s.First.UTC(),
+		Last:         s.Last.UTC(),
+		Count:        s.Count,
+		GapCount:     s.GapCount,
+	}, nil
+}
@@ -24,6 +24,9 @@ type SimpleMovingAverage struct {
 	PriceType    string    `db:"price_type"`
 	Time         time.Time `db:"time"`
 	Data         []byte    `db:"data"`
+
+	AlgorithmVersion int       `db:"algorithm_version"`
+	ComputedAt       time.Time `db:"computed_at"`
 }
 
 // FromModel converts the model to an entity.
@@ -86,6 +89,8 @@ func FromModelListToEntityList(
 	periodNb int,
 	priceType candlestick.PriceType,
 	ts *timeseries.TimeSerie[float64],
+	algorithmVersion int,
+	computedAt time.Time,
 ) ([]SimpleMovingAverage, error) {
 	entities := make([]SimpleMovingAverage, 0, ts.Len())
 	err := ts.Loop(func(t time.Time, p float64) (bool, error) {
@@ -101,6 +106,8 @@ func FromModelListToEntityList(
 		}); err != nil {
 			return false, err
 		}
+		point.AlgorithmVersion = algorithmVersion
+		point.ComputedAt = computedAt.UTC()
 
 		entities = append(entities, point)
 		return false, nil
@@ -137,6 +144,9 @@ func FromEntitiesToMap(entities []SimpleMovingAverage) []map[string]interface{}
 			"price_type":    e.PriceType,
 			"time":          e.Time.UTC(),
 			"data":          e.Data,
+
+			"algorithm_version": e.AlgorithmVersion,
+			"computed_at":       e.ComputedAt.UTC(),
 		})
 	}
 
@@ -2,10 +2,14 @@ package db
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/cryptellation/candlesticks/pkg/candlestick"
 	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/sma"
 	timeserie "github.com/cryptellation/timeseries"
 	"github.com/stretchr/testify/suite"
 )
@@ -161,3 +165,249 @@ func (suite *IndicatorsSuite) TestUpsertSMAActivity() {
 		suite.Require().Equal(expectedValue, value, i)
 	}
 }
+
+// TestReadSMAActivityWithMinAlgorithmVersion tests that the ReadSMAActivity activity
+// excludes the points computed with an older algorithm version.
+func (suite *IndicatorsSuite) TestReadSMAActivityWithMinAlgorithmVersion() {
+	writeParams := UpsertSMAActivityParams{
+		Exchange:     "exchange",
+		Pair:         "ETC-USDT",
+		Period:       period.M1,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		TimeSerie: timeserie.New[float64]().
+			Set(time.Unix(0, 0), 1).
+			Set(time.Unix(60, 0), 2),
+		AlgorithmVersion: 1,
+		ComputedAt:       time.Unix(3600, 0),
+	}
+
+	// Write data with an old algorithm version
+	_, err := suite.DB.UpsertSMAActivity(context.Background(), writeParams)
+	suite.Require().NoError(err)
+
+	// Overwrite part of the data with a newer algorithm version
+	writeParams.TimeSerie = timeserie.New[float64]().Set(time.Unix(60, 0), 3)
+	writeParams.AlgorithmVersion = 2
+	_, err = suite.DB.UpsertSMAActivity(context.Background(), writeParams)
+	suite.Require().NoError(err)
+
+	// Read data with the newer algorithm version
+	rts, err := suite.DB.ReadSMAActivity(context.Background(), ReadSMAActivityParams{
+		Exchange:            writeParams.Exchange,
+		Pair:                writeParams.Pair,
+		Period:              writeParams.Period,
+		PeriodNumber:        writeParams.PeriodNumber,
+		PriceType:           writeParams.PriceType,
+		Start:               time.Unix(0, 0),
+		End:                 time.Unix(60, 0),
+		MinAlgorithmVersion: 2,
+	})
+	suite.Require().NoError(err)
+
+	// Check that only the up to date point is returned
+	suite.Require().Equal(1, rts.Data.Len())
+	value, exists := rts.Data.Get(time.Unix(60, 0))
+	suite.Require().True(exists)
+	suite.Require().Equal(3.0, value)
+}
+
+// TestInvalidateSMAActivity tests the InvalidateSMAActivity activity.
+func (suite *IndicatorsSuite) TestInvalidateSMAActivity() {
+	ts := timeserie.New[float64]()
+	for i := int64(0); i <= 10; i++ {
+		ts.Set(time.Unix(i*60, 0), float64(i))
+	}
+
+	// Write data for different period numbers and another pair
+	writeParams := UpsertSMAActivityParams{
+		Exchange:     "exchange",
+		Pair:         "ETC-USDT",
+		Period:       period.M1,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		TimeSerie:    ts,
+	}
+	_, err := suite.DB.UpsertSMAActivity(context.Background(), writeParams)
+	suite.Require().NoError(err)
+	p := writeParams
+	p.PeriodNumber = 1
+	_, err = suite.DB.UpsertSMAActivity(context.Background(), p)
+	suite.Require().NoError(err)
+	p = writeParams
+	p.Pair = "BTC-USDC"
+	_, err = suite.DB.UpsertSMAActivity(context.Background(), p)
+	suite.Require().NoError(err)
+
+	// Invalidate candlesticks from 120 to 180
+	res, err := suite.DB.InvalidateSMAActivity(context.Background(), InvalidateSMAActivityParams{
+		Exchange: writeParams.Exchange,
+		Pair:     writeParams.Pair,
+		Period:   writeParams.Period,
+		Start:    time.Unix(120, 0),
+		End:      time.Unix(180, 0),
+	})
+	suite.Require().NoError(err)
+
+	// Check that points depending on these candlesticks are removed:
+	// from 120 to 300 for 3 periods and from 120 to 180 for 1 period.
+	suite.Require().Equal(6, res.InvalidatedCount)
+
+	cases := []struct {
+		Pair         string
+		PeriodNumber int
+		Missing      []int64
+	}{
+		{Pair: "ETC-USDT", PeriodNumber: 3, Missing: []int64{120, 180, 240, 300}},
+		{Pair: "ETC-USDT", PeriodNumber: 1, Missing: []int64{120, 180}},
+		{Pair: "BTC-USDC", PeriodNumber: 3},
+	}
+	for i, c := range cases {
+		rts, err := suite.DB.ReadSMAActivity(context.Background(), ReadSMAActivityParams{
+			Exchange:     writeParams.Exchange,
+			Pair:         c.Pair,
+			Period:       writeParams.Period,
+			PeriodNumber: c.PeriodNumber,
+			PriceType:    writeParams.PriceType,
+			Start:        time.Unix(0, 0),
+			End:          time.Unix(600, 0),
+		})
+		suite.Require().NoError(err, i)
+		suite.Require().Equal(ts.Len()-len(c.Missing), rts.Data.Len(), i)
+		for _, m := range c.Missing {
+			_, exists := rts.Data.Get(time.Unix(m, 0))
+			suite.Require().False(exists, i)
+		}
+	}
+}
+
+// TestListSeriesActivity tests the ListSeriesActivity activity.
+func (suite *IndicatorsSuite) TestListSeriesActivity() {
+	// Write a series with two gaps and another one without
+	writeParams := UpsertSMAActivityParams{
+		Exchange:     "exchange",
+		Pair:         "ETC-USDT",
+		Period:       period.M1,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		TimeSerie: timeserie.New[float64]().
+			Set(time.Unix(0, 0), 1).
+			Set(time.Unix(120, 0), 2).
+			Set(time.Unix(180, 0), 3).
+			Set(time.Unix(360, 0), 4),
+	}
+	_, err := suite.DB.UpsertSMAActivity(context.Background(), writeParams)
+	suite.Require().NoError(err)
+	p := writeParams
+	p.PeriodNumber = 8
+	p.TimeSerie = timeserie.New[float64]().
+		Set(time.Unix(0, 0), 1).
+		Set(time.Unix(60, 0), 2)
+	_, err = suite.DB.UpsertSMAActivity(context.Background(), p)
+	suite.Require().NoError(err)
+
+	// List series
+	res, err := suite.DB.ListSeriesActivity(context.Background(), ListSeriesActivityParams{})
+	suite.Require().NoError(err)
+
+	// Check series
+	suite.Require().Len(res.Series, 2)
+	suite.Require().Equal(3, res.Series[0].PeriodNumber)
+	suite.Require().Equal(time.Unix(0, 0).UTC(), res.Series[0].First.UTC())
+	suite.Require().Equal(time.Unix(360, 0).UTC(), res.Series[0].Last.UTC())
+	suite.Require().Equal(4, res.Series[0].Count)
+	suite.Require().Equal(2, res.Series[0].GapCount)
+	suite.Require().Equal(8, res.Series[1].PeriodNumber)
+	suite.Require().Equal(2, res.Series[1].Count)
+	suite.Require().Equal(0, res.Series[1].GapCount)
+}
+
+// TestGapReportActivity tests the GapReportActivity activity.
+func (suite *IndicatorsSuite) TestGapReportActivity() {
+	writeParams := UpsertSMAActivityParams{
+		Exchange:     "exchange",
+		Pair:         "ETC-USDT",
+		Period:       period.M1,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		TimeSerie: timeserie.New[float64]().
+			Set(time.Unix(60, 0), 1).
+			Set(time.Unix(120, 0), 0).
+			Set(time.Unix(240, 0), 2),
+		AlgorithmVersion: sma.AlgorithmVersion,
+	}
+	_, err := suite.DB.UpsertSMAActivity(context.Background(), writeParams)
+	suite.Require().NoError(err)
+
+	// Write a point computed with an older algorithm version
+	staleParams := writeParams
+	staleParams.TimeSerie = timeserie.New[float64]().Set(time.Unix(180, 0), 3)
+	staleParams.AlgorithmVersion = sma.AlgorithmVersion - 1
+	_, err = suite.DB.UpsertSMAActivity(context.Background(), staleParams)
+	suite.Require().NoError(err)
+
+	// Get report
+	res, err := suite.DB.GapReportActivity(context.Background(), GapReportActivityParams{
+		Exchange:     writeParams.Exchange,
+		Pair:         writeParams.Pair,
+		Period:       writeParams.Period,
+		PeriodNumber: writeParams.PeriodNumber,
+		PriceType:    writeParams.PriceType,
+		Start:        time.Unix(0, 0),
+		End:          time.Unix(300, 0),
+	})
+	suite.Require().NoError(err)
+
+	// Check report
+	suite.Require().Len(res.Missing, 3)
+	suite.Require().True(res.Missing[0].Start.Equal(time.Unix(0, 0)))
+	suite.Require().True(res.Missing[0].End.Equal(time.Unix(0, 0)))
+	suite.Require().True(res.Missing[1].Start.Equal(time.Unix(180, 0)))
+	suite.Require().True(res.Missing[1].End.Equal(time.Unix(180, 0)))
+	suite.Require().True(res.Missing[2].Start.Equal(time.Unix(300, 0)))
+	suite.Require().True(res.Missing[2].End.Equal(time.Unix(300, 0)))
+	suite.Require().Len(res.Invalid, 1)
+	suite.Require().True(res.Invalid[0].Start.Equal(time.Unix(120, 0)))
+	suite.Require().True(res.Invalid[0].End.Equal(time.Unix(120, 0)))
+}
+
+// TestExportActivity tests the ExportActivity activity.
+func (suite *IndicatorsSuite) TestExportActivity() {
+	writeParams := UpsertSMAActivityParams{
+		Exchange:     "exchange",
+		Pair:         "ETC-USDT",
+		Period:       period.M1,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		TimeSerie: timeserie.New[float64]().
+			Set(time.Unix(0, 0), 1).
+			Set(time.Unix(60, 0), 1.5).
+			Set(time.Unix(120, 0), 2),
+	}
+	_, err := suite.DB.UpsertSMAActivity(context.Background(), writeParams)
+	suite.Require().NoError(err)
+	p := writeParams
+	p.Pair = "BTC-USDT"
+	_, err = suite.DB.UpsertSMAActivity(context.Background(), p)
+	suite.Require().NoError(err)
+
+	// Export one series, without the first point
+	path := filepath.Join(suite.T().TempDir(), "export.csv")
+	res, err := suite.DB.ExportActivity(context.Background(), ExportActivityParams{
+		Pair:   "ETC-USDT",
+		Start:  time.Unix(60, 0),
+		Format: api.ExportFormatCSV,
+		Path:   path,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(2, res.Count)
+
+	// Check file
+	content, err := os.ReadFile(path)
+	suite.Require().NoError(err)
+	suite.Require().Equal(
+		"exchange,pair,period,length,price_type,time,value\n"+
+			"exchange,ETC-USDT,M1,3,close,1970-01-01T00:01:00Z,1.5\n"+
+			"exchange,ETC-USDT,M1,3,close,1970-01-01T00:02:00Z,2\n",
+		string(content))
+}
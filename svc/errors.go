@@ -0,0 +1,86 @@
+package svc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cryptellation/candlesticks/pkg/pair"
+	"github.com/cryptellation/candlesticks/svc/exchanges"
+	"github.com/cryptellation/sma/api"
+	"go.temporal.io/sdk/temporal"
+)
+
+// newInvalidArgumentError wraps the error into a non retryable application
+// error with the invalid argument type.
+func newInvalidArgumentError(err error) error {
+	return temporal.NewNonRetryableApplicationError(err.Error(), api.ErrTypeInvalidArgument, err)
+}
+
+// newNotFoundError wraps the error into a non retryable application error
+// with the not found type.
+func newNotFoundError(err error) error {
+	return temporal.NewNonRetryableApplicationError(err.Error(), api.ErrTypeNotFound, err)
+}
+
+// isNotFoundError returns true if the error is an application error with the
+// not found type.
+func isNotFoundError(err error) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.Type() == api.ErrTypeNotFound
+}
+
+// newUpstreamUnavailableError wraps the error into a retryable application
+// error with the upstream unavailable type, as the upstream can be back later.
+func newUpstreamUnavailableError(err error) error {
+	return temporal.NewApplicationError(err.Error(), api.ErrTypeUpstreamUnavailable, err)
+}
+
+// candlesticksUnsupportedErrors are the errors returned by the candlesticks
+// service when the exchange or the pair does not exist.
+var candlesticksUnsupportedErrors = []error{
+	exchanges.ErrInexistantExchange,
+	pair.ErrInvalidPair,
+}
+
+// isCandlesticksUnsupportedError returns true if the error of the candlesticks
+// child workflow is due to an unknown exchange or pair. As the errors identity
+// is lost through Temporal, they are matched on their message.
+func isCandlesticksUnsupportedError(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		var appErr *temporal.ApplicationError
+		if !errors.As(err, &appErr) {
+			return false
+		}
+
+		for _, e := range candlesticksUnsupportedErrors {
+			if strings.Contains(appErr.Message(), e.Error()) {
+				return true
+			}
+		}
+		err = appErr
+	}
+	return false
+}
+
+// fromCandlesticksError converts an error of the candlesticks child workflow:
+//   - cancellations are returned as is, so that they propagate;
+//   - application errors keep their type and retryability, so that an invalid
+//     or not found request is reported as such;
+//   - other errors (e.g. timeouts) are retryable upstream unavailable errors.
+func fromCandlesticksError(msg string, err error) error {
+	if temporal.IsCanceledError(err) {
+		return err
+	}
+
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) {
+		msg = fmt.Sprintf("%s: %s", msg, err)
+		return temporal.NewApplicationErrorWithOptions(msg, appErr.Type(), temporal.ApplicationErrorOptions{
+			NonRetryable: appErr.NonRetryable(),
+			Cause:        err,
+		})
+	}
+
+	return newUpstreamUnavailableError(fmt.Errorf("%s: %w", msg, err))
+}
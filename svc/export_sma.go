@@ -0,0 +1,66 @@
+package svc
+
+import (
+	"path/filepath"
+
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/svc/db"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ExportWorkflow writes the cached SMA points of the selected series to a file
+// of the export directory. The points are streamed from the database, so the
+// range can be larger than what fits in memory, and the file only appears once
+// complete.
+func (wf *workflows) ExportWorkflow(
+	ctx workflow.Context,
+	params api.ExportWorkflowParams,
+) (api.ExportWorkflowResults, error) {
+	logger := workflow.GetLogger(ctx)
+
+	// Validate parameters
+	if err := params.Validate(); err != nil {
+		return api.ExportWorkflowResults{}, newInvalidArgumentError(err)
+	}
+
+	path := filepath.Join(wf.exportDirectory, params.FileName)
+	logger.Info("Got request for SMA export",
+		"exchange", params.Exchange,
+		"pair", params.Pair,
+		"period", params.Period,
+		"period_number", params.PeriodNumber,
+		"price_type", params.PriceType,
+		"start", params.Start,
+		"end", params.End,
+		"format", params.Format,
+		"path", path)
+
+	// Export the points from DB
+	var exportDBRes db.ExportActivityResults
+	err := workflow.ExecuteActivity(
+		workflow.WithActivityOptions(ctx, db.ExportActivityOptions()),
+		wf.db.ExportActivity, db.ExportActivityParams{
+			Exchange:     params.Exchange,
+			Pair:         params.Pair,
+			Period:       params.Period,
+			PeriodNumber: params.PeriodNumber,
+			PriceType:    params.PriceType,
+			Start:        params.Start,
+			End:          params.End,
+			Format:       params.Format,
+			Path:         path,
+			Overwrite:    params.Overwrite,
+		}).Get(ctx, &exportDBRes)
+	if err != nil {
+		return api.ExportWorkflowResults{}, err
+	}
+
+	logger.Info("Exported SMA points",
+		"count", exportDBRes.Count,
+		"path", path)
+
+	return api.ExportWorkflowResults{
+		Path:  filepath.Clean(params.FileName),
+		Count: exportDBRes.Count,
+	}, nil
+}
@@ -0,0 +1,156 @@
+//go:build unit
+// +build unit
+
+package svc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/svc/db"
+	"github.com/cryptellation/sma/svc/db/mem"
+	timeserie "github.com/cryptellation/timeseries"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
+
+type ExportSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+	db  *mem.Activities
+	dir string
+	wf  *workflows
+}
+
+func (suite *ExportSuite) SetupTest() {
+	suite.env = suite.NewTestWorkflowEnvironment()
+	suite.db = mem.New()
+	suite.dir = suite.T().TempDir()
+	suite.wf = New(suite.db, WithExportDirectory(suite.dir)).(*workflows)
+
+	suite.env.RegisterActivityWithOptions(suite.db.ExportActivity, activity.RegisterOptions{
+		Name: db.ExportActivityName,
+	})
+}
+
+func (suite *ExportSuite) TestExportWorkflow() {
+	_, err := suite.db.UpsertSMAActivity(context.Background(), db.UpsertSMAActivityParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		TimeSerie: timeserie.New[float64]().
+			Set(time.Unix(0, 0), 1000).
+			Set(time.Unix(60, 0), 1250),
+	})
+	suite.Require().NoError(err)
+
+	// WHEN exporting the series
+	suite.env.ExecuteWorkflow(suite.wf.ExportWorkflow, api.ExportWorkflowParams{
+		Exchange: "exchange",
+		Format:   api.ExportFormatCSV,
+		FileName: "eth/export.csv",
+	})
+
+	// THEN the file is created in the export directory
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.ExportWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Equal(filepath.Join("eth", "export.csv"), res.Path)
+	suite.Require().Equal(2, res.Count)
+
+	content, err := os.ReadFile(filepath.Join(suite.dir, res.Path))
+	suite.Require().NoError(err)
+	suite.Require().Equal(
+		"exchange,pair,period,length,price_type,time,value\n"+
+			"exchange,ETH-USDT,M1,3,close,1970-01-01T00:00:00Z,1000\n"+
+			"exchange,ETH-USDT,M1,3,close,1970-01-01T00:01:00Z,1250\n",
+		string(content))
+}
+
+func (suite *ExportSuite) TestExportWorkflowInvalidArguments() {
+	cases := []struct {
+		Name   string
+		Params api.ExportWorkflowParams
+	}{
+		{
+			Name:   "unknown format",
+			Params: api.ExportWorkflowParams{Format: "xlsx", FileName: "export.xlsx"},
+		},
+		{
+			Name:   "missing file name",
+			Params: api.ExportWorkflowParams{Format: api.ExportFormatCSV},
+		},
+		{
+			Name:   "file outside of the export directory",
+			Params: api.ExportWorkflowParams{Format: api.ExportFormatCSV, FileName: "../export.csv"},
+		},
+		{
+			Name: "end before start",
+			Params: api.ExportWorkflowParams{
+				Start:    time.Unix(60, 0),
+				End:      time.Unix(0, 0),
+				Format:   api.ExportFormatCSV,
+				FileName: "export.csv",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		// WHEN executing the workflow
+		env := suite.NewTestWorkflowEnvironment()
+		env.ExecuteWorkflow(suite.wf.ExportWorkflow, c.Params)
+
+		// THEN the error is an invalid argument
+		var appErr *temporal.ApplicationError
+		suite.Require().ErrorAs(env.GetWorkflowError(), &appErr, c.Name)
+		suite.Require().Equal(api.ErrTypeInvalidArgument, appErr.Type(), c.Name)
+	}
+}
+
+func (suite *ExportSuite) TestExportWorkflowExistingFile() {
+	// GIVEN a previous export of the file
+	path := filepath.Join(suite.dir, "export.csv")
+	suite.Require().NoError(os.WriteFile(path, []byte("previous"), 0o600))
+	params := api.ExportWorkflowParams{Format: api.ExportFormatCSV, FileName: "export.csv"}
+
+	// WHEN exporting to the same file without overwriting it
+	suite.env.ExecuteWorkflow(suite.wf.ExportWorkflow, params)
+
+	// THEN the export fails and the previous file is untouched
+	var appErr *temporal.ApplicationError
+	suite.Require().ErrorAs(suite.env.GetWorkflowError(), &appErr)
+	suite.Require().Equal(api.ErrTypeAlreadyExists, appErr.Type())
+	content, err := os.ReadFile(path)
+	suite.Require().NoError(err)
+	suite.Require().Equal("previous", string(content))
+
+	// WHEN exporting to the same file and overwriting it
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivityWithOptions(suite.db.ExportActivity, activity.RegisterOptions{
+		Name: db.ExportActivityName,
+	})
+	params.Overwrite = true
+	env.ExecuteWorkflow(suite.wf.ExportWorkflow, params)
+
+	// THEN the file is replaced
+	suite.Require().NoError(env.GetWorkflowError())
+	content, err = os.ReadFile(path)
+	suite.Require().NoError(err)
+	suite.Require().Equal("exchange,pair,period,length,price_type,time,value\n", string(content))
+}
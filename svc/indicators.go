@@ -16,6 +16,26 @@ type SMA interface {
 		ctx workflow.Context,
 		params api.ListWorkflowParams,
 	) (api.ListWorkflowResults, error)
+
+	InvalidateSMAWorkflow(
+		ctx workflow.Context,
+		params api.InvalidateWorkflowParams,
+	) (api.InvalidateWorkflowResults, error)
+
+	ListSeriesWorkflow(
+		ctx workflow.Context,
+		params api.ListSeriesWorkflowParams,
+	) (api.ListSeriesWorkflowResults, error)
+
+	ExportWorkflow(
+		ctx workflow.Context,
+		params api.ExportWorkflowParams,
+	) (api.ExportWorkflowResults, error)
+
+	VerifyWorkflow(
+		ctx workflow.Context,
+		params api.VerifyWorkflowParams,
+	) (api.VerifyWorkflowResults, error)
 }
 
 // Check that the workflows implements the SMA interface.
@@ -24,14 +44,27 @@ var _ SMA = &workflows{}
 type workflows struct {
 	db           db.DB
 	candlesticks clients.WfClient
+
+	maxPeriodNumber    int
+	maxRangePoints     int
+	checkSupportedPair bool
+	exportDirectory    string
 }
 
 // New creates a new SMA instance.
-func New(db db.DB) SMA {
-	return &workflows{
+func New(db db.DB, opts ...Option) SMA {
+	wf := &workflows{
 		candlesticks: clients.NewWfClient(),
 		db:           db,
+		// Default to the working directory of the worker
+		exportDirectory: ".",
+	}
+
+	for _, opt := range opts {
+		opt(wf)
 	}
+
+	return wf
 }
 
 // Register registers the workflows to the worker.
@@ -40,6 +73,22 @@ func (wf *workflows) Register(worker worker.Worker) {
 		Name: api.ListWorkflowName,
 	})
 
+	worker.RegisterWorkflowWithOptions(wf.InvalidateSMAWorkflow, workflow.RegisterOptions{
+		Name: api.InvalidateWorkflowName,
+	})
+
+	worker.RegisterWorkflowWithOptions(wf.ListSeriesWorkflow, workflow.RegisterOptions{
+		Name: api.ListSeriesWorkflowName,
+	})
+
+	worker.RegisterWorkflowWithOptions(wf.ExportWorkflow, workflow.RegisterOptions{
+		Name: api.ExportWorkflowName,
+	})
+
+	worker.RegisterWorkflowWithOptions(wf.VerifyWorkflow, workflow.RegisterOptions{
+		Name: api.VerifyWorkflowName,
+	})
+
 	worker.RegisterWorkflowWithOptions(ServiceInfoWorkflow, workflow.RegisterOptions{
 		Name: api.ServiceInfoWorkflowName,
 	})
@@ -0,0 +1,55 @@
+package svc
+
+import (
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/svc/db"
+	"go.temporal.io/sdk/workflow"
+)
+
+// InvalidateSMAWorkflow removes every cached SMA point, whatever its period
+// number and price type, that has been computed from candlesticks in the
+// given range. It should be called when these candlesticks have been corrected.
+func (wf *workflows) InvalidateSMAWorkflow(
+	ctx workflow.Context,
+	params api.InvalidateWorkflowParams,
+) (api.InvalidateWorkflowResults, error) {
+	logger := workflow.GetLogger(ctx)
+
+	// Validate parameters
+	if err := params.Validate(); err != nil {
+		return api.InvalidateWorkflowResults{}, newInvalidArgumentError(err)
+	}
+
+	// Process the params
+	params.Start = params.Period.RoundTime(params.Start)
+	params.End = params.Period.RoundTime(params.End)
+
+	logger.Info("Got request for SMA invalidation",
+		"start", params.Start,
+		"end", params.End,
+		"pair", params.Pair,
+		"exchange", params.Exchange,
+		"period", params.Period)
+
+	// Remove the affected points from DB
+	var invalidateDBRes db.InvalidateSMAActivityResults
+	err := workflow.ExecuteActivity(
+		workflow.WithActivityOptions(ctx, db.DefaultActivityOptions()),
+		wf.db.InvalidateSMAActivity, db.InvalidateSMAActivityParams{
+			Exchange: params.Exchange,
+			Pair:     params.Pair,
+			Period:   params.Period,
+			Start:    params.Start,
+			End:      params.End,
+		}).Get(ctx, &invalidateDBRes)
+	if err != nil {
+		return api.InvalidateWorkflowResults{}, err
+	}
+
+	logger.Info("Invalidated SMA points",
+		"count", invalidateDBRes.InvalidatedCount)
+
+	return api.InvalidateWorkflowResults{
+		InvalidatedCount: invalidateDBRes.InvalidatedCount,
+	}, nil
+}
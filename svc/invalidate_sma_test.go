@@ -0,0 +1,143 @@
+//go:build unit
+// +build unit
+
+package svc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/svc/db"
+	"github.com/cryptellation/sma/svc/db/mem"
+	timeserie "github.com/cryptellation/timeseries"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestInvalidateSuite(t *testing.T) {
+	suite.Run(t, new(InvalidateSuite))
+}
+
+type InvalidateSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+	db  *mem.Activities
+	wf  *workflows
+}
+
+func (suite *InvalidateSuite) SetupTest() {
+	suite.env = suite.NewTestWorkflowEnvironment()
+	suite.db = mem.New()
+	suite.wf = New(suite.db).(*workflows)
+
+	suite.env.RegisterActivityWithOptions(suite.db.InvalidateSMAActivity, activity.RegisterOptions{
+		Name: db.InvalidateSMAActivityName,
+	})
+}
+
+// upsert stores a point for each minute from 00:00 to 00:10 for the period number.
+func (suite *InvalidateSuite) upsert(periodNumber int) {
+	ts := timeserie.New[float64]()
+	for i := int64(0); i <= 10; i++ {
+		ts.Set(time.Unix(i*60, 0), float64(1000+i))
+	}
+
+	_, err := suite.db.UpsertSMAActivity(context.Background(), db.UpsertSMAActivityParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		PeriodNumber: periodNumber,
+		PriceType:    candlestick.PriceTypeIsClose,
+		TimeSerie:    ts,
+	})
+	suite.Require().NoError(err)
+}
+
+// times returns the times, in seconds, of the stored points for the period number.
+func (suite *InvalidateSuite) times(periodNumber int) []int64 {
+	res, err := suite.db.ReadSMAActivity(context.Background(), db.ReadSMAActivityParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		PeriodNumber: periodNumber,
+		PriceType:    candlestick.PriceTypeIsClose,
+		Start:        time.Unix(0, 0),
+		End:          time.Unix(600, 0),
+	})
+	suite.Require().NoError(err)
+
+	times := make([]int64, 0, res.Data.Len())
+	_ = res.Data.Loop(func(t time.Time, _ float64) (bool, error) {
+		times = append(times, t.Unix())
+		return false, nil
+	})
+	return times
+}
+
+func (suite *InvalidateSuite) TestInvalidateSMAWorkflow() {
+	// GIVEN series with 1 and 3 periods
+	suite.upsert(1)
+	suite.upsert(3)
+
+	// WHEN invalidating the candlesticks from 00:02 to 00:03 (not rounded)
+	suite.env.ExecuteWorkflow(suite.wf.InvalidateSMAWorkflow, api.InvalidateWorkflowParams{
+		Exchange: "exchange",
+		Pair:     "ETH-USDT",
+		Period:   period.M1,
+		Start:    time.Unix(130, 0),
+		End:      time.Unix(190, 0),
+	})
+
+	// THEN the points computed from these candlesticks are removed, up to
+	// (period_number-1) periods after the end of the range
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.InvalidateWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Equal(2+4, res.InvalidatedCount)
+	suite.Require().Equal([]int64{0, 60, 240, 300, 360, 420, 480, 540, 600}, suite.times(1))
+	suite.Require().Equal([]int64{0, 60, 360, 420, 480, 540, 600}, suite.times(3))
+}
+
+func (suite *InvalidateSuite) TestInvalidateSMAWorkflowInvalidArguments() {
+	valid := api.InvalidateWorkflowParams{
+		Exchange: "exchange",
+		Pair:     "ETH-USDT",
+		Period:   period.M1,
+		Start:    time.Unix(0, 0),
+		End:      time.Unix(60, 0),
+	}
+
+	cases := []struct {
+		Name   string
+		Modify func(p *api.InvalidateWorkflowParams)
+	}{
+		{Name: "missing exchange", Modify: func(p *api.InvalidateWorkflowParams) { p.Exchange = "" }},
+		{Name: "missing pair", Modify: func(p *api.InvalidateWorkflowParams) { p.Pair = "" }},
+		{Name: "invalid pair format", Modify: func(p *api.InvalidateWorkflowParams) { p.Pair = "ETHUSDT" }},
+		{Name: "invalid period", Modify: func(p *api.InvalidateWorkflowParams) { p.Period = "M2" }},
+		{Name: "missing start", Modify: func(p *api.InvalidateWorkflowParams) { p.Start = time.Time{} }},
+		{Name: "end before start", Modify: func(p *api.InvalidateWorkflowParams) { p.End = time.Unix(-60, 0) }},
+	}
+
+	for _, c := range cases {
+		params := valid
+		c.Modify(&params)
+
+		// WHEN executing the workflow
+		env := suite.NewTestWorkflowEnvironment()
+		env.ExecuteWorkflow(suite.wf.InvalidateSMAWorkflow, params)
+
+		// THEN the error is an invalid argument
+		var appErr *temporal.ApplicationError
+		suite.Require().ErrorAs(env.GetWorkflowError(), &appErr, c.Name)
+		suite.Require().Equal(api.ErrTypeInvalidArgument, appErr.Type(), c.Name)
+	}
+}
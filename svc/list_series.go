@@ -0,0 +1,42 @@
+package svc
+
+import (
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/svc/db"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ListSeriesWorkflow returns the inventory of the cached SMA series.
+func (wf *workflows) ListSeriesWorkflow(
+	ctx workflow.Context,
+	_ api.ListSeriesWorkflowParams,
+) (api.ListSeriesWorkflowResults, error) {
+	// Get series from DB
+	var listDBRes db.ListSeriesActivityResults
+	err := workflow.ExecuteActivity(
+		workflow.WithActivityOptions(ctx, db.DefaultActivityOptions()),
+		wf.db.ListSeriesActivity, db.ListSeriesActivityParams{}).Get(ctx, &listDBRes)
+	if err != nil {
+		return api.ListSeriesWorkflowResults{}, err
+	}
+
+	// Convert to API structs
+	series := make([]api.SeriesInfo, 0, len(listDBRes.Series))
+	for _, s := range listDBRes.Series {
+		series = append(series, api.SeriesInfo{
+			Exchange:     s.Exchange,
+			Pair:         s.Pair,
+			Period:       s.Period,
+			PeriodNumber: s.PeriodNumber,
+			PriceType:    s.PriceType,
+			First:        s.First,
+			Last:         s.Last,
+			Count:        s.Count,
+			GapCount:     s.GapCount,
+		})
+	}
+
+	return api.ListSeriesWorkflowResults{
+		Series: series,
+	}, nil
+}
@@ -0,0 +1,100 @@
+//go:build unit
+// +build unit
+
+package svc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/svc/db"
+	"github.com/cryptellation/sma/svc/db/mem"
+	timeserie "github.com/cryptellation/timeseries"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestListSeriesSuite(t *testing.T) {
+	suite.Run(t, new(ListSeriesSuite))
+}
+
+type ListSeriesSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+	db  *mem.Activities
+	wf  *workflows
+}
+
+func (suite *ListSeriesSuite) SetupTest() {
+	suite.env = suite.NewTestWorkflowEnvironment()
+	suite.db = mem.New()
+	suite.wf = New(suite.db).(*workflows)
+
+	suite.env.RegisterActivityWithOptions(suite.db.ListSeriesActivity, activity.RegisterOptions{
+		Name: db.ListSeriesActivityName,
+	})
+}
+
+func (suite *ListSeriesSuite) upsert(pair string, periodNumber int, ts *timeserie.TimeSerie[float64]) {
+	_, err := suite.db.UpsertSMAActivity(context.Background(), db.UpsertSMAActivityParams{
+		Exchange:     "exchange",
+		Pair:         pair,
+		Period:       period.M1,
+		PeriodNumber: periodNumber,
+		PriceType:    candlestick.PriceTypeIsClose,
+		TimeSerie:    ts,
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *ListSeriesSuite) TestListSeriesWorkflow() {
+	// GIVEN a series with a gap and a complete one
+	suite.upsert("ETH-USDT", 3, timeserie.New[float64]().
+		Set(time.Unix(0, 0), 1000).
+		Set(time.Unix(60, 0), 1100).
+		Set(time.Unix(240, 0), 1200))
+	suite.upsert("BTC-USDT", 20, timeserie.New[float64]().
+		Set(time.Unix(60, 0), 30000).
+		Set(time.Unix(120, 0), 31000))
+
+	// WHEN listing the series
+	suite.env.ExecuteWorkflow(suite.wf.ListSeriesWorkflow, api.ListSeriesWorkflowParams{})
+
+	// THEN every series is returned, sorted, with its boundaries and gaps
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.ListSeriesWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Len(res.Series, 2)
+
+	btc, eth := res.Series[0], res.Series[1]
+	suite.Require().Equal("BTC-USDT", btc.Pair)
+	suite.Require().Equal(20, btc.PeriodNumber)
+	suite.Require().Equal(2, btc.Count)
+	suite.Require().Zero(btc.GapCount)
+
+	suite.Require().Equal("exchange", eth.Exchange)
+	suite.Require().Equal("ETH-USDT", eth.Pair)
+	suite.Require().Equal(period.M1, eth.Period)
+	suite.Require().Equal(3, eth.PeriodNumber)
+	suite.Require().Equal(candlestick.PriceTypeIsClose, eth.PriceType)
+	suite.Require().True(eth.First.Equal(time.Unix(0, 0)))
+	suite.Require().True(eth.Last.Equal(time.Unix(240, 0)))
+	suite.Require().Equal(3, eth.Count)
+	suite.Require().Equal(1, eth.GapCount)
+}
+
+func (suite *ListSeriesSuite) TestListSeriesWorkflowEmpty() {
+	suite.env.ExecuteWorkflow(suite.wf.ListSeriesWorkflow, api.ListSeriesWorkflowParams{})
+
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.ListSeriesWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Empty(res.Series)
+}
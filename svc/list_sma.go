@@ -1,69 +1,92 @@
 package svc
 
 import (
-	"errors"
+	"fmt"
 	"time"
 
 	candlesticksapi "github.com/cryptellation/candlesticks/api"
 	"github.com/cryptellation/candlesticks/pkg/candlestick"
 	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/logging"
+	"github.com/cryptellation/sma/pkg/metrics"
 	"github.com/cryptellation/sma/pkg/sma"
+	"github.com/cryptellation/sma/pkg/tracing"
 	"github.com/cryptellation/sma/svc/db"
 	"github.com/cryptellation/timeseries"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
 
-// validateListWorkflowParams checks if the required fields are filled and valid.
-func validateListWorkflowParams(params api.ListWorkflowParams) error {
-	if params.Exchange == "" {
-		return errors.New("exchange is required")
+// validateListWorkflowLimits checks that the request is within the configured limits.
+func (wf *workflows) validateListWorkflowLimits(params api.ListWorkflowParams) error {
+	if wf.maxPeriodNumber > 0 && params.PeriodNumber > wf.maxPeriodNumber {
+		return fmt.Errorf("period_number must be lower or equal to %d", wf.maxPeriodNumber)
 	}
-	if params.Pair == "" {
-		return errors.New("pair is required")
-	}
-	if params.Period == "" {
-		return errors.New("period is required")
-	}
-	if params.PeriodNumber <= 0 {
-		return errors.New("period_number must be greater than 0")
-	}
-	if params.PriceType == "" {
-		return errors.New("price_type is required")
-	}
-	if params.Start.IsZero() {
-		return errors.New("start time is required")
-	}
-	if params.End.IsZero() {
-		return errors.New("end time is required")
-	}
-	if params.End.Before(params.Start) {
-		return errors.New("end time must be after start time")
+
+	count := int(params.End.Sub(params.Start)/params.Period.Duration()) + 1
+	if wf.maxRangePoints > 0 && count > wf.maxRangePoints {
+		return fmt.Errorf("range must contain at most %d points (requested %d)", wf.maxRangePoints, count)
 	}
+
 	return nil
 }
 
+// checkSupportedPairWithCandlesticks checks with the candlesticks service that the exchange
+// and pair are supported.
+func (wf *workflows) checkSupportedPairWithCandlesticks(ctx workflow.Context, params api.ListWorkflowParams) error {
+	_, err := wf.candlesticks.ListCandlesticks(ctx, candlesticksapi.ListCandlesticksWorkflowParams{
+		Exchange: params.Exchange,
+		Pair:     params.Pair,
+		Period:   params.Period,
+		Start:    &params.Start,
+		End:      &params.Start,
+		Limit:    1,
+	}, &workflow.ChildWorkflowOptions{
+		TaskQueue: candlesticksapi.WorkerTaskQueueName,
+	})
+
+	// Only the unknown exchange or pair errors mean that the pair is not
+	// supported, other errors come from the candlesticks service failing.
+	switch {
+	case err == nil:
+		return nil
+	case temporal.IsCanceledError(err):
+		return err
+	case isCandlesticksUnsupportedError(err):
+		return newInvalidArgumentError(fmt.Errorf("exchange %q and pair %q are not supported: %w",
+			params.Exchange, params.Pair, err))
+	default:
+		return newUpstreamUnavailableError(fmt.Errorf("checking supported pair: %w", err))
+	}
+}
+
 // ListSMAWorkflow returns the SMA points for a given pair and exchange.
 func (wf *workflows) ListSMAWorkflow(
 	ctx workflow.Context,
 	params api.ListWorkflowParams,
 ) (api.ListWorkflowResults, error) {
-	logger := workflow.GetLogger(ctx)
+	logger := listLogger(ctx, params)
 
-	// Validate parameters
-	if err := validateListWorkflowParams(params); err != nil {
-		return api.ListWorkflowResults{}, err
+	// Validate and process the params
+	params, err := params.Normalize()
+	if err != nil {
+		return api.ListWorkflowResults{}, newInvalidArgumentError(err)
 	}
 
-	// Process the params
-	params.Start = params.Period.RoundTime(params.Start)
-	params.End = params.Period.RoundTime(params.End)
+	// Check limits and support
+	if err := wf.validateListWorkflowLimits(params); err != nil {
+		return api.ListWorkflowResults{}, newInvalidArgumentError(err)
+	}
+	if wf.checkSupportedPair {
+		if err := wf.checkSupportedPairWithCandlesticks(ctx, params); err != nil {
+			return api.ListWorkflowResults{}, err
+		}
+	}
 
 	logger.Info("Got request for SMA",
 		"start", params.Start,
-		"end", params.End,
-		"pair", params.Pair,
-		"exchange", params.Exchange,
-		"period", params.Period)
+		"end", params.End)
 
 	// Get SMA from DB and check if it's up to date
 	res, upToDate, err := wf.getSMAFromDBAndCheck(ctx, params)
@@ -71,8 +94,10 @@ func (wf *workflows) ListSMAWorkflow(
 		return api.ListWorkflowResults{}, err
 	} else if upToDate {
 		logger.Info("SMA is up to date, returning")
-		return res, nil
+		countListRequest(ctx, metrics.ResultHit)
+		return params.FormatResults(res.Data), nil
 	}
+	countListRequest(ctx, metrics.ResultRecompute)
 
 	// Generate and upsert SMA points
 	logger.Info("SMA is outdated, invalid or missing points, recalculating")
@@ -81,14 +106,29 @@ func (wf *workflows) ListSMAWorkflow(
 		return api.ListWorkflowResults{}, err
 	}
 
-	return res, err
+	return params.FormatResults(res.Data), nil
+}
+
+// listLogger returns the workflow logger with the fields of the requested series.
+func listLogger(ctx workflow.Context, params api.ListWorkflowParams) log.Logger {
+	return logging.WithSeries(workflow.GetLogger(ctx),
+		params.Exchange, params.Pair, params.Period, params.PeriodNumber, params.PriceType)
+}
+
+// countListRequest counts the list request with its result in the metrics.
+func countListRequest(ctx workflow.Context, result string) {
+	workflow.GetMetricsHandler(ctx).
+		WithTags(map[string]string{metrics.TagResult: result}).
+		Counter(metrics.ListRequestsCounter).Inc(1)
 }
 
 func (wf *workflows) getSMAFromDBAndCheck(
 	ctx workflow.Context,
 	params api.ListWorkflowParams,
 ) (res api.ListWorkflowResults, upToDate bool, err error) {
-	logger := workflow.GetLogger(ctx)
+	logger := listLogger(ctx, params)
+	ctx, end := tracing.StartWorkflowSpan(ctx, "ReadCachedSMA")
+	defer func() { end(err) }()
 
 	// Get cached SMA from DB
 	var readDBRes db.ReadSMAActivityResults
@@ -102,12 +142,15 @@ func (wf *workflows) getSMAFromDBAndCheck(
 			PriceType:    params.PriceType,
 			Start:        params.Start,
 			End:          params.End,
+			// Points from an older algorithm are stale and will be recomputed
+			MinAlgorithmVersion: sma.AlgorithmVersion,
 		}).Get(ctx, &readDBRes)
 	if err != nil {
 		return api.ListWorkflowResults{}, false, err
 	}
 	logger.Info("Got SMA points",
 		"count", readDBRes.Data.Len())
+	workflow.GetMetricsHandler(ctx).Counter(metrics.PointsReadCounter).Inc(int64(readDBRes.Data.Len()))
 
 	// Check if current candlestick will be requested
 	// If that's the case, we'll need to recalculate the SMA as the value has changed
@@ -158,13 +201,16 @@ func (wf *workflows) generateAndUpsertSMA(
 	}, nil
 }
 
-func (wf *workflows) generateSMA(
+// listCandlesticks returns the candlesticks needed to compute the SMA points
+// of the range.
+func (wf *workflows) listCandlesticks(
 	ctx workflow.Context,
 	params api.ListWorkflowParams,
-) ([]api.SMADataPoint, error) {
-	// Get necessary candlesticks
+) (*candlestick.List, error) {
 	start := params.Start.Add(-params.Period.Duration() * time.Duration(params.PeriodNumber))
-	res, err := wf.candlesticks.ListCandlesticks(ctx, candlesticksapi.ListCandlesticksWorkflowParams{
+	fetchStart := workflow.Now(ctx)
+	spanCtx, end := tracing.StartWorkflowSpan(ctx, "ListCandlesticks")
+	res, err := wf.candlesticks.ListCandlesticks(spanCtx, candlesticksapi.ListCandlesticksWorkflowParams{
 		Exchange: params.Exchange,
 		Pair:     params.Pair,
 		Period:   params.Period,
@@ -173,8 +219,13 @@ func (wf *workflows) generateSMA(
 	}, &workflow.ChildWorkflowOptions{
 		TaskQueue: candlesticksapi.WorkerTaskQueueName,
 	})
+	end(err)
+	workflow.GetMetricsHandler(ctx).Timer(metrics.CandlesticksFetchTimer).Record(workflow.Now(ctx).Sub(fetchStart))
 	if err != nil {
-		return nil, err
+		return nil, fromCandlesticksError("listing candlesticks", err)
+	} else if len(res.List) == 0 {
+		return nil, newNotFoundError(fmt.Errorf("no candlesticks found for %s %s %s from %s to %s",
+			params.Exchange, params.Pair, params.Period, start, params.End))
 	}
 
 	// Set the candlesticks to the list
@@ -185,32 +236,30 @@ func (wf *workflows) generateSMA(
 		}
 	}
 
+	return csList, nil
+}
+
+func (wf *workflows) generateSMA(
+	ctx workflow.Context,
+	params api.ListWorkflowParams,
+) ([]api.SMADataPoint, error) {
+	// Get necessary candlesticks
+	csList, err := wf.listCandlesticks(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate SMAs and return them
-	ts, err := sma.TimeSerie(sma.TimeSerieParams{
+	_, end := tracing.StartWorkflowSpan(ctx, "ComputeSMA")
+	data, err := sma.DataPoints(sma.TimeSerieParams{
 		Candlesticks: csList,
 		PriceType:    params.PriceType,
 		Start:        params.Start,
 		End:          params.End,
 		PeriodNumber: params.PeriodNumber,
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert timeserie to slice of structs
-	data := make([]api.SMADataPoint, 0, ts.Len())
-	err = ts.Loop(func(t time.Time, v float64) (bool, error) {
-		data = append(data, api.SMADataPoint{
-			Time:  t,
-			Value: v,
-		})
-		return false, nil
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return data, nil
+	end(err)
+	return data, err
 }
 
 func (wf *workflows) upsertSMA(
@@ -218,7 +267,7 @@ func (wf *workflows) upsertSMA(
 	params api.ListWorkflowParams,
 	data []api.SMADataPoint,
 ) error {
-	logger := workflow.GetLogger(ctx)
+	logger := listLogger(ctx, params)
 	logger.Info("Upserting SMA points",
 		"count", len(data))
 
@@ -228,15 +277,25 @@ func (wf *workflows) upsertSMA(
 		ts.Set(d.Time, d.Value)
 	}
 
+	spanCtx, end := tracing.StartWorkflowSpan(ctx, "UpsertSMA")
 	var upsertDBRes db.UpsertSMAActivityResults
-	return workflow.ExecuteActivity(
-		workflow.WithActivityOptions(ctx, db.DefaultActivityOptions()),
+	err := workflow.ExecuteActivity(
+		workflow.WithActivityOptions(spanCtx, db.DefaultActivityOptions()),
 		wf.db.UpsertSMAActivity, db.UpsertSMAActivityParams{
-			Exchange:     params.Exchange,
-			Pair:         params.Pair,
-			Period:       params.Period,
-			PeriodNumber: params.PeriodNumber,
-			PriceType:    params.PriceType,
-			TimeSerie:    ts,
+			Exchange:         params.Exchange,
+			Pair:             params.Pair,
+			Period:           params.Period,
+			PeriodNumber:     params.PeriodNumber,
+			PriceType:        params.PriceType,
+			TimeSerie:        ts,
+			AlgorithmVersion: sma.AlgorithmVersion,
+			ComputedAt:       workflow.Now(ctx),
 		}).Get(ctx, &upsertDBRes)
+	end(err)
+	if err != nil {
+		return err
+	}
+
+	workflow.GetMetricsHandler(ctx).Counter(metrics.PointsUpsertedCounter).Inc(int64(len(data)))
+	return nil
 }
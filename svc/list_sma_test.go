@@ -0,0 +1,324 @@
+//go:build unit
+// +build unit
+
+package svc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	candlesticksapi "github.com/cryptellation/candlesticks/api"
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/svc/db"
+	"github.com/cryptellation/sma/svc/db/mem"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestListSMASuite(t *testing.T) {
+	suite.Run(t, new(ListSMASuite))
+}
+
+type ListSMASuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+	db  *mem.Activities
+	wf  *workflows
+}
+
+func (suite *ListSMASuite) SetupTest() {
+	suite.env = suite.NewTestWorkflowEnvironment()
+	suite.db = mem.New()
+	suite.wf = New(suite.db).(*workflows)
+
+	suite.env.RegisterActivityWithOptions(suite.db.ReadSMAActivity, activity.RegisterOptions{
+		Name: db.ReadSMAActivityName,
+	})
+	suite.env.RegisterActivityWithOptions(suite.db.UpsertSMAActivity, activity.RegisterOptions{
+		Name: db.UpsertSMAActivityName,
+	})
+	suite.env.RegisterWorkflowWithOptions(suite.wf.ListSMAWorkflow, workflow.RegisterOptions{
+		Name: api.ListWorkflowName,
+	})
+	suite.env.RegisterWorkflowWithOptions(listCandlesticksStub, workflow.RegisterOptions{
+		Name: candlesticksapi.ListCandlesticksWorkflowName,
+	})
+}
+
+func (suite *ListSMASuite) TestListSMAWorkflowComputesAndCaches() {
+	params := api.ListWorkflowParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		Start:        time.Unix(120, 0),
+		End:          time.Unix(180, 0),
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+	}
+
+	suite.env.OnWorkflow(candlesticksapi.ListCandlesticksWorkflowName, mock.Anything, mock.Anything).
+		Return(candlesticksapi.ListCandlesticksWorkflowResults{
+			List: []candlestick.Candlestick{
+				{Time: time.Unix(0, 0), Close: 1000},
+				{Time: time.Unix(60, 0), Close: 1500},
+				{Time: time.Unix(120, 0), Close: 1250},
+				{Time: time.Unix(180, 0), Close: 1300},
+			},
+		}, nil).Once()
+
+	// WHEN executing the workflow
+	suite.env.ExecuteWorkflow(api.ListWorkflowName, params)
+
+	// THEN the result is computed
+	suite.Require().True(suite.env.IsWorkflowCompleted())
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.ListWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Len(res.Data, 2)
+	suite.Require().Equal(1250.0, res.Data[0].Value)
+	suite.Require().Equal(1350.0, res.Data[1].Value)
+
+	// AND the result is stored in the database
+	stored, err := suite.db.ReadSMAActivity(context.Background(), db.ReadSMAActivityParams{
+		Exchange:     params.Exchange,
+		Pair:         params.Pair,
+		Period:       params.Period,
+		PeriodNumber: params.PeriodNumber,
+		PriceType:    params.PriceType,
+		Start:        params.Start,
+		End:          params.End,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(2, stored.Data.Len())
+}
+
+func (suite *ListSMASuite) TestListSMAWorkflowCompactFormat() {
+	params := api.ListWorkflowParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		Start:        time.Unix(120, 0),
+		End:          time.Unix(180, 0),
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		Format:       api.ResultFormatCompact,
+	}
+
+	suite.env.OnWorkflow(candlesticksapi.ListCandlesticksWorkflowName, mock.Anything, mock.Anything).
+		Return(candlesticksapi.ListCandlesticksWorkflowResults{
+			List: []candlestick.Candlestick{
+				{Time: time.Unix(0, 0), Close: 1000},
+				{Time: time.Unix(60, 0), Close: 1500},
+				{Time: time.Unix(120, 0), Close: 1250},
+				{Time: time.Unix(180, 0), Close: 1300},
+			},
+		}, nil).Once()
+
+	// WHEN executing the workflow with the compact format
+	suite.env.ExecuteWorkflow(api.ListWorkflowName, params)
+
+	// THEN the result is packed
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.ListWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Empty(res.Data)
+	suite.Require().NotNil(res.Compact)
+	suite.Require().True(res.Compact.Start.Equal(params.Start))
+	suite.Require().Equal([]float64{1250, 1350}, res.Compact.Values)
+	suite.Require().Equal([]byte{0x00}, res.Compact.Missing)
+}
+
+func (suite *ListSMASuite) TestListSMAWorkflowErrors() {
+	params := api.ListWorkflowParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		Start:        time.Unix(120, 0),
+		End:          time.Unix(180, 0),
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+	}
+
+	cases := []struct {
+		Name              string
+		Params            func(p api.ListWorkflowParams) api.ListWorkflowParams
+		Options           []Option
+		CandlesticksErr   error
+		ExpectedType      string
+		ExpectedRetryable bool
+	}{
+		{
+			Name: "invalid argument",
+			Params: func(p api.ListWorkflowParams) api.ListWorkflowParams {
+				p.Pair = ""
+				return p
+			},
+			ExpectedType: api.ErrTypeInvalidArgument,
+		},
+		{
+			Name: "malformed pair",
+			Params: func(p api.ListWorkflowParams) api.ListWorkflowParams {
+				p.Pair = "ETHUSDT"
+				return p
+			},
+			ExpectedType: api.ErrTypeInvalidArgument,
+		},
+		{
+			Name: "unknown period",
+			Params: func(p api.ListWorkflowParams) api.ListWorkflowParams {
+				p.Period = "M2"
+				return p
+			},
+			ExpectedType: api.ErrTypeInvalidArgument,
+		},
+		{
+			Name: "unknown price type",
+			Params: func(p api.ListWorkflowParams) api.ListWorkflowParams {
+				p.PriceType = "median"
+				return p
+			},
+			ExpectedType: api.ErrTypeInvalidArgument,
+		},
+		{
+			Name:         "period number over limit",
+			Params:       func(p api.ListWorkflowParams) api.ListWorkflowParams { return p },
+			Options:      []Option{WithMaxPeriodNumber(2)},
+			ExpectedType: api.ErrTypeInvalidArgument,
+		},
+		{
+			Name:         "range over limit",
+			Params:       func(p api.ListWorkflowParams) api.ListWorkflowParams { return p },
+			Options:      []Option{WithMaxRangePoints(1)},
+			ExpectedType: api.ErrTypeInvalidArgument,
+		},
+		{
+			Name:            "unsupported pair",
+			Params:          func(p api.ListWorkflowParams) api.ListWorkflowParams { return p },
+			Options:         []Option{WithSupportedPairCheck(true)},
+			CandlesticksErr: temporal.NewApplicationError(`inexistant exchange: "exchange"`, "*fmt.wrapError"),
+			ExpectedType:    api.ErrTypeInvalidArgument,
+		},
+		{
+			Name:            "invalid pair",
+			Params:          func(p api.ListWorkflowParams) api.ListWorkflowParams { return p },
+			Options:         []Option{WithSupportedPairCheck(true)},
+			CandlesticksErr: temporal.NewApplicationError("invalid pair symbol: ETH-USDT", "*fmt.wrapError"),
+			ExpectedType:    api.ErrTypeInvalidArgument,
+		},
+		{
+			Name:              "supported pair check failure",
+			Params:            func(p api.ListWorkflowParams) api.ListWorkflowParams { return p },
+			Options:           []Option{WithSupportedPairCheck(true)},
+			CandlesticksErr:   temporal.NewApplicationError("rate limited", "RateLimited"),
+			ExpectedType:      api.ErrTypeUpstreamUnavailable,
+			ExpectedRetryable: true,
+		},
+		{
+			Name:         "not found",
+			Params:       func(p api.ListWorkflowParams) api.ListWorkflowParams { return p },
+			ExpectedType: api.ErrTypeNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		env := suite.NewTestWorkflowEnvironment()
+		env.RegisterActivityWithOptions(suite.db.ReadSMAActivity, activity.RegisterOptions{
+			Name: db.ReadSMAActivityName,
+		})
+		env.RegisterWorkflowWithOptions(listCandlesticksStub, workflow.RegisterOptions{
+			Name: candlesticksapi.ListCandlesticksWorkflowName,
+		})
+		env.OnWorkflow(candlesticksapi.ListCandlesticksWorkflowName, mock.Anything, mock.Anything).
+			Return(candlesticksapi.ListCandlesticksWorkflowResults{}, c.CandlesticksErr).Maybe()
+
+		// WHEN executing the workflow
+		wf := New(suite.db, c.Options...).(*workflows)
+		env.ExecuteWorkflow(wf.ListSMAWorkflow, c.Params(params))
+
+		// THEN the error has the expected type and retry policy
+		var appErr *temporal.ApplicationError
+		suite.Require().ErrorAs(env.GetWorkflowError(), &appErr, c.Name)
+		suite.Require().Equal(c.ExpectedType, appErr.Type(), c.Name)
+		suite.Require().Equal(!c.ExpectedRetryable, appErr.NonRetryable(), c.Name)
+	}
+}
+
+func (suite *ListSMASuite) TestListSMAWorkflowCandlesticksErrors() {
+	params := api.ListWorkflowParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		Start:        time.Unix(120, 0),
+		End:          time.Unix(180, 0),
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+	}
+
+	cases := []struct {
+		Name              string
+		CandlesticksErr   error
+		ExpectedType      string
+		ExpectedRetryable bool
+	}{
+		{
+			Name:            "invalid argument",
+			CandlesticksErr: temporal.NewNonRetryableApplicationError("invalid", api.ErrTypeInvalidArgument, nil),
+			ExpectedType:    api.ErrTypeInvalidArgument,
+		},
+		{
+			Name:            "not found",
+			CandlesticksErr: temporal.NewNonRetryableApplicationError("not found", api.ErrTypeNotFound, nil),
+			ExpectedType:    api.ErrTypeNotFound,
+		},
+		{
+			Name:              "retryable upstream error",
+			CandlesticksErr:   temporal.NewApplicationError("rate limited", "RateLimited"),
+			ExpectedType:      "RateLimited",
+			ExpectedRetryable: true,
+		},
+		{
+			Name:              "timeout",
+			CandlesticksErr:   temporal.NewTimeoutError(enumspb.TIMEOUT_TYPE_START_TO_CLOSE, nil),
+			ExpectedType:      api.ErrTypeUpstreamUnavailable,
+			ExpectedRetryable: true,
+		},
+	}
+
+	for _, c := range cases {
+		env := suite.NewTestWorkflowEnvironment()
+		env.RegisterActivityWithOptions(suite.db.ReadSMAActivity, activity.RegisterOptions{
+			Name: db.ReadSMAActivityName,
+		})
+		env.RegisterWorkflowWithOptions(listCandlesticksStub, workflow.RegisterOptions{
+			Name: candlesticksapi.ListCandlesticksWorkflowName,
+		})
+		env.OnWorkflow(candlesticksapi.ListCandlesticksWorkflowName, mock.Anything, mock.Anything).
+			Return(candlesticksapi.ListCandlesticksWorkflowResults{}, c.CandlesticksErr)
+
+		// WHEN executing the workflow
+		env.ExecuteWorkflow(suite.wf.ListSMAWorkflow, params)
+
+		// THEN the error keeps the upstream type and retryability
+		var appErr *temporal.ApplicationError
+		suite.Require().ErrorAs(env.GetWorkflowError(), &appErr, c.Name)
+		suite.Require().Equal(c.ExpectedType, appErr.Type(), c.Name)
+		suite.Require().Equal(!c.ExpectedRetryable, appErr.NonRetryable(), c.Name)
+	}
+}
+
+func listCandlesticksStub(
+	_ workflow.Context,
+	_ candlesticksapi.ListCandlesticksWorkflowParams,
+) (candlesticksapi.ListCandlesticksWorkflowResults, error) {
+	return candlesticksapi.ListCandlesticksWorkflowResults{}, nil
+}
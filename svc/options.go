@@ -0,0 +1,36 @@
+package svc
+
+// Option is an option for the SMA workflows.
+type Option func(wf *workflows)
+
+// WithMaxPeriodNumber sets the maximum period number that can be requested.
+// A value of 0 means no limit.
+func WithMaxPeriodNumber(n int) Option {
+	return func(wf *workflows) {
+		wf.maxPeriodNumber = n
+	}
+}
+
+// WithMaxRangePoints sets the maximum count of points that can be requested
+// at once. A value of 0 means no limit.
+func WithMaxRangePoints(n int) Option {
+	return func(wf *workflows) {
+		wf.maxRangePoints = n
+	}
+}
+
+// WithSupportedPairCheck enables the verification, with the candlesticks
+// service, that the requested exchange and pair are supported before
+// processing a request.
+func WithSupportedPairCheck(enabled bool) Option {
+	return func(wf *workflows) {
+		wf.checkSupportedPair = enabled
+	}
+}
+
+// WithExportDirectory sets the directory where the exported files are created.
+func WithExportDirectory(dir string) Option {
+	return func(wf *workflows) {
+		wf.exportDirectory = dir
+	}
+}
@@ -0,0 +1,229 @@
+package svc
+
+import (
+	"time"
+
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/clients"
+	"github.com/cryptellation/sma/pkg/metrics"
+	"github.com/cryptellation/sma/pkg/sma"
+	"github.com/cryptellation/sma/svc/db"
+	"github.com/cryptellation/timeseries"
+	"go.temporal.io/sdk/workflow"
+)
+
+// VerifyWorkflow recomputes the cached SMA points of a range from the current
+// candlesticks and reports the ones that differ by more than the tolerance,
+// replacing them by the recomputed values if requested. It catches the points
+// cached by a buggy algorithm or computed before a candlestick correction.
+func (wf *workflows) VerifyWorkflow(
+	ctx workflow.Context,
+	params api.VerifyWorkflowParams,
+) (api.VerifyWorkflowResults, error) {
+	logger := workflow.GetLogger(ctx)
+
+	// Validate parameters
+	if err := params.Validate(); err != nil {
+		return api.VerifyWorkflowResults{}, newInvalidArgumentError(err)
+	}
+
+	// Process the params
+	listParams := api.ListWorkflowParams{
+		Exchange:     params.Exchange,
+		Pair:         params.Pair,
+		Period:       params.Period,
+		Start:        params.Period.RoundTime(params.Start),
+		End:          params.Period.RoundTime(params.End),
+		PeriodNumber: params.PeriodNumber,
+		PriceType:    params.PriceType,
+	}
+
+	logger.Info("Got request for SMA verification",
+		"start", listParams.Start,
+		"end", listParams.End,
+		"pair", params.Pair,
+		"exchange", params.Exchange,
+		"period", params.Period,
+		"sample_size", params.SampleSize)
+
+	// Read, recompute and compare the cached points by windows of at most the
+	// maximum range points, so that neither the cached points nor the
+	// candlesticks of the range are loaded at once
+	res := api.VerifyWorkflowResults{
+		Mismatches: make([]api.VerifyMismatch, 0),
+	}
+	repair := make([]api.SMADataPoint, 0)
+	sampler := newPointSampler(listParams, params.SampleSize)
+	for _, window := range wf.verifyWindows(listParams) {
+		// Get cached SMA from DB, whatever their algorithm version
+		var readDBRes db.ReadSMAActivityResults
+		err := workflow.ExecuteActivity(
+			workflow.WithActivityOptions(ctx, db.DefaultActivityOptions()),
+			wf.db.ReadSMAActivity, db.ReadSMAActivityParams{
+				Exchange:     window.Exchange,
+				Pair:         window.Pair,
+				Period:       window.Period,
+				PeriodNumber: window.PeriodNumber,
+				PriceType:    window.PriceType,
+				Start:        window.Start,
+				End:          window.End,
+			}).Get(ctx, &readDBRes)
+		if err != nil {
+			return api.VerifyWorkflowResults{}, err
+		}
+		workflow.GetMetricsHandler(ctx).Counter(metrics.PointsReadCounter).Inc(int64(readDBRes.Data.Len()))
+
+		cached := sampler.sample(readDBRes.Data)
+		if cached.Len() == 0 {
+			continue
+		}
+
+		// Only list the candlesticks needed by the selected points
+		window.Start, _, _ = cached.First()
+		window.End, _, _ = cached.Last()
+		computed, err := wf.recomputeSMA(ctx, window, cached)
+		if isNotFoundError(err) {
+			// No candlesticks for the whole window: none of its points can be verified
+			computed, err = timeseries.New[float64](), nil
+		}
+		if err != nil {
+			return api.VerifyWorkflowResults{}, err
+		}
+
+		// Points without recomputed value (e.g. missing candlesticks) cannot
+		// be verified: report them apart from the checked ones
+		_ = cached.Loop(func(t time.Time, _ float64) (bool, error) {
+			if _, exists := computed.Get(t); exists {
+				res.Checked++
+			} else {
+				res.Unverifiable = append(res.Unverifiable, t)
+			}
+			return false, nil
+		})
+
+		for _, m := range sma.Compare(cached, computed, params.Tolerance) {
+			res.Mismatches = append(res.Mismatches, api.VerifyMismatch{
+				Time:     m.Time,
+				Cached:   m.Stored,
+				Computed: m.Computed,
+			})
+			repair = append(repair, api.SMADataPoint{Time: m.Time, Value: m.Computed})
+		}
+	}
+	logger.Info("Verified SMA points",
+		"checked", res.Checked,
+		"mismatches", len(res.Mismatches),
+		"unverifiable", len(res.Unverifiable))
+
+	// Repair the mismatching points if requested
+	if params.Repair && len(repair) > 0 {
+		if err := wf.upsertSMA(ctx, listParams, repair); err != nil {
+			return api.VerifyWorkflowResults{}, err
+		}
+		res.Repaired = len(repair)
+	}
+
+	return res, nil
+}
+
+// pointSampler selects the cached points to verify, evenly spread on a
+// range: the range periods are split into buckets and the first cached point
+// of each bucket is kept. As the buckets only depend on the range, the cached
+// points can be given window by window, in order.
+type pointSampler struct {
+	start      time.Time
+	interval   time.Duration
+	total      int
+	count      int
+	lastBucket int
+}
+
+// newPointSampler creates a sampler keeping at most count points of the range,
+// or every point if count is 0 or not lower than the range periods.
+func newPointSampler(params api.ListWorkflowParams, count int) *pointSampler {
+	interval := params.Period.Duration()
+	return &pointSampler{
+		start:      params.Start,
+		interval:   interval,
+		total:      int(params.End.Sub(params.Start)/interval) + 1,
+		count:      count,
+		lastBucket: -1,
+	}
+}
+
+// sample returns the selected points of the timeserie.
+func (s *pointSampler) sample(ts *timeseries.TimeSerie[float64]) *timeseries.TimeSerie[float64] {
+	if s.count == 0 || s.count >= s.total {
+		return ts
+	}
+
+	sample := timeseries.New[float64]()
+	_ = ts.Loop(func(t time.Time, v float64) (bool, error) {
+		// Keep the point if it is the first one of its bucket
+		bucket := int(t.Sub(s.start)/s.interval) * s.count / s.total
+		if bucket != s.lastBucket {
+			sample.Set(t, v)
+			s.lastBucket = bucket
+		}
+		return false, nil
+	})
+	return sample
+}
+
+// verifyWindows splits the range into windows of at most the maximum range
+// points, or of clients.DefaultListChunkSize points if there is no maximum.
+func (wf *workflows) verifyWindows(params api.ListWorkflowParams) []api.ListWorkflowParams {
+	size := wf.maxRangePoints
+	if size <= 0 {
+		size = clients.DefaultListChunkSize
+	}
+
+	interval := params.Period.Duration()
+	windows := make([]api.ListWorkflowParams, 0)
+	for start := params.Start; !start.After(params.End); {
+		window := params
+		window.Start = start
+		window.End = start.Add(interval * time.Duration(size-1))
+		if window.End.After(params.End) {
+			window.End = params.End
+		}
+		windows = append(windows, window)
+		start = window.End.Add(interval)
+	}
+	return windows
+}
+
+// recomputeSMA computes the SMA points at the times of the given points. The
+// points that cannot be computed are missing from the result.
+func (wf *workflows) recomputeSMA(
+	ctx workflow.Context,
+	params api.ListWorkflowParams,
+	points *timeseries.TimeSerie[float64],
+) (*timeseries.TimeSerie[float64], error) {
+	csList, err := wf.listCandlesticks(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compute the whole range once, then keep the points to verify
+	ts, err := sma.TimeSerie(sma.TimeSerieParams{
+		Candlesticks: csList,
+		PriceType:    params.PriceType,
+		Start:        params.Start,
+		End:          params.End,
+		PeriodNumber: params.PeriodNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	computed := timeseries.New[float64]()
+	_ = points.Loop(func(t time.Time, _ float64) (bool, error) {
+		// A zero value is invalid (no candlesticks): the point is not recomputed
+		if v, exists := ts.Get(t); exists && v != 0 {
+			computed.Set(t, v)
+		}
+		return false, nil
+	})
+	return computed, nil
+}
@@ -0,0 +1,229 @@
+//go:build unit
+// +build unit
+
+package svc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	candlesticksapi "github.com/cryptellation/candlesticks/api"
+	"github.com/cryptellation/candlesticks/pkg/candlestick"
+	"github.com/cryptellation/candlesticks/pkg/period"
+	"github.com/cryptellation/sma/api"
+	"github.com/cryptellation/sma/pkg/sma"
+	"github.com/cryptellation/sma/svc/db"
+	"github.com/cryptellation/sma/svc/db/mem"
+	timeserie "github.com/cryptellation/timeseries"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestVerifySuite(t *testing.T) {
+	suite.Run(t, new(VerifySuite))
+}
+
+type VerifySuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+	db  *mem.Activities
+	wf  *workflows
+}
+
+func (suite *VerifySuite) SetupTest() {
+	suite.env = suite.NewTestWorkflowEnvironment()
+	suite.db = mem.New()
+	suite.wf = New(suite.db).(*workflows)
+
+	suite.env.RegisterActivityWithOptions(suite.db.ReadSMAActivity, activity.RegisterOptions{
+		Name: db.ReadSMAActivityName,
+	})
+	suite.env.RegisterActivityWithOptions(suite.db.UpsertSMAActivity, activity.RegisterOptions{
+		Name: db.UpsertSMAActivityName,
+	})
+	suite.env.RegisterWorkflowWithOptions(listCandlesticksStub, workflow.RegisterOptions{
+		Name: candlesticksapi.ListCandlesticksWorkflowName,
+	})
+	suite.env.OnWorkflow(candlesticksapi.ListCandlesticksWorkflowName, mock.Anything, mock.Anything).
+		Return(candlesticksapi.ListCandlesticksWorkflowResults{
+			List: []candlestick.Candlestick{
+				{Time: time.Unix(0, 0), Close: 1000},
+				{Time: time.Unix(60, 0), Close: 1500},
+				{Time: time.Unix(120, 0), Close: 1250},
+				{Time: time.Unix(180, 0), Close: 1300},
+			},
+		}, nil).Maybe()
+}
+
+func (suite *VerifySuite) params() api.VerifyWorkflowParams {
+	return api.VerifyWorkflowParams{
+		Exchange:     "exchange",
+		Pair:         "ETH-USDT",
+		Period:       period.M1,
+		PeriodNumber: 3,
+		PriceType:    candlestick.PriceTypeIsClose,
+		Start:        time.Unix(120, 0),
+		End:          time.Unix(180, 0),
+		Tolerance:    0.001,
+	}
+}
+
+func (suite *VerifySuite) upsert(ts *timeserie.TimeSerie[float64]) {
+	p := suite.params()
+	_, err := suite.db.UpsertSMAActivity(context.Background(), db.UpsertSMAActivityParams{
+		Exchange:         p.Exchange,
+		Pair:             p.Pair,
+		Period:           p.Period,
+		PeriodNumber:     p.PeriodNumber,
+		PriceType:        p.PriceType,
+		TimeSerie:        ts,
+		AlgorithmVersion: sma.AlgorithmVersion,
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *VerifySuite) TestVerifyWorkflowRepairs() {
+	// The second point is wrong: it should be 1350
+	suite.upsert(timeserie.New[float64]().
+		Set(time.Unix(120, 0), 1250).
+		Set(time.Unix(180, 0), 1400))
+
+	// WHEN verifying with repair
+	params := suite.params()
+	params.Repair = true
+	suite.env.ExecuteWorkflow(suite.wf.VerifyWorkflow, params)
+
+	// THEN the mismatching point is reported and repaired
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.VerifyWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Equal(2, res.Checked)
+	suite.Require().Equal(1, res.Repaired)
+	suite.Require().Len(res.Mismatches, 1)
+	suite.Require().True(res.Mismatches[0].Time.Equal(time.Unix(180, 0)))
+	suite.Require().Equal(1400.0, res.Mismatches[0].Cached)
+	suite.Require().Equal(1350.0, res.Mismatches[0].Computed)
+
+	stored, err := suite.db.ReadSMAActivity(context.Background(), db.ReadSMAActivityParams{
+		Exchange:     params.Exchange,
+		Pair:         params.Pair,
+		Period:       params.Period,
+		PeriodNumber: params.PeriodNumber,
+		PriceType:    params.PriceType,
+		Start:        params.Start,
+		End:          params.End,
+	})
+	suite.Require().NoError(err)
+	v, _ := stored.Data.Get(time.Unix(180, 0))
+	suite.Require().Equal(1350.0, v)
+}
+
+func (suite *VerifySuite) TestVerifyWorkflowSample() {
+	suite.upsert(timeserie.New[float64]().
+		Set(time.Unix(120, 0), 1250).
+		Set(time.Unix(180, 0), 1400))
+
+	// WHEN verifying only one point without repair
+	params := suite.params()
+	params.SampleSize = 1
+	suite.env.ExecuteWorkflow(suite.wf.VerifyWorkflow, params)
+
+	// THEN only the first point is checked
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.VerifyWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Equal(1, res.Checked)
+	suite.Require().Empty(res.Mismatches)
+	suite.Require().Zero(res.Repaired)
+}
+
+func (suite *VerifySuite) TestVerifyWorkflowChunks() {
+	suite.upsert(timeserie.New[float64]().
+		Set(time.Unix(120, 0), 1250).
+		Set(time.Unix(180, 0), 1400))
+
+	// WHEN verifying with ranges of one point at most
+	suite.wf.maxRangePoints = 1
+	suite.env.ExecuteWorkflow(suite.wf.VerifyWorkflow, suite.params())
+
+	// THEN the candlesticks are listed for each point
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.VerifyWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Equal(2, res.Checked)
+	suite.Require().Len(res.Mismatches, 1)
+	suite.env.AssertNumberOfCalls(suite.T(), candlesticksapi.ListCandlesticksWorkflowName, 2)
+}
+
+func (suite *VerifySuite) TestVerifyWorkflowUnverifiable() {
+	// GIVEN a cached point after the last candlestick
+	suite.upsert(timeserie.New[float64]().
+		Set(time.Unix(120, 0), 1250).
+		Set(time.Unix(600, 0), 1400))
+
+	// WHEN verifying with repair
+	params := suite.params()
+	params.End = time.Unix(600, 0)
+	params.Repair = true
+	suite.env.ExecuteWorkflow(suite.wf.VerifyWorkflow, params)
+
+	// THEN the point is reported as unverifiable, not as checked nor repaired
+	suite.Require().NoError(suite.env.GetWorkflowError())
+	var res api.VerifyWorkflowResults
+	suite.Require().NoError(suite.env.GetWorkflowResult(&res))
+	suite.Require().Equal(1, res.Checked)
+	suite.Require().Empty(res.Mismatches)
+	suite.Require().Zero(res.Repaired)
+	suite.Require().Len(res.Unverifiable, 1)
+	suite.Require().True(res.Unverifiable[0].Equal(time.Unix(600, 0)))
+}
+
+func (suite *VerifySuite) TestPointSampler() {
+	ts := timeserie.New[float64]()
+	for i := 0; i < 10; i++ {
+		ts.Set(time.Unix(int64(i)*60, 0), float64(i))
+	}
+	params := api.ListWorkflowParams{
+		Period: period.M1,
+		Start:  time.Unix(0, 0),
+		End:    time.Unix(540, 0),
+	}
+
+	suite.Require().Equal(10, newPointSampler(params, 0).sample(ts).Len())
+	suite.Require().Equal(10, newPointSampler(params, 20).sample(ts).Len())
+	suite.Require().Equal(3, newPointSampler(params, 3).sample(ts).Len())
+	suite.Require().Equal(1, newPointSampler(params, 1).sample(ts).Len())
+
+	// The same points are selected when given window by window
+	sampler := newPointSampler(params, 3)
+	first := sampler.sample(ts.Extract(time.Unix(0, 0), time.Unix(240, 0), 0))
+	second := sampler.sample(ts.Extract(time.Unix(300, 0), time.Unix(540, 0), 0))
+	suite.Require().NoError(first.Merge(*second, nil))
+	suite.Require().Equal(newPointSampler(params, 3).sample(ts).ToArray(), first.ToArray())
+}
+
+func (suite *VerifySuite) TestVerifyWindows() {
+	params := api.ListWorkflowParams{
+		Period: period.M1,
+		Start:  time.Unix(0, 0),
+		End:    time.Unix(540, 0),
+	}
+
+	// Windows of the maximum range points
+	suite.wf.maxRangePoints = 4
+	windows := suite.wf.verifyWindows(params)
+	suite.Require().Len(windows, 3)
+	suite.Require().True(windows[0].End.Equal(time.Unix(180, 0)))
+	suite.Require().True(windows[1].Start.Equal(time.Unix(240, 0)))
+	suite.Require().True(windows[2].End.Equal(time.Unix(540, 0)))
+
+	// Single window without maximum
+	suite.wf.maxRangePoints = 0
+	suite.Require().Len(suite.wf.verifyWindows(params), 1)
+}
@@ -25,7 +25,8 @@ type EndToEndSuite struct {
 
 func (suite *EndToEndSuite) SetupSuite() {
 	tc, err := temporalclient.Dial(temporalclient.Options{
-		HostPort: viper.GetString(configs.EnvTemporalAddress),
+		HostPort:  viper.GetString(configs.EnvTemporalAddress),
+		Namespace: viper.GetString(configs.EnvTemporalNamespace),
 	})
 	suite.Require().NoError(err)
 	suite.temporalclient = tc